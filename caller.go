@@ -0,0 +1,83 @@
+package loguru
+
+import (
+	"runtime"
+	"sync"
+)
+
+var helpers = struct {
+	sync.RWMutex
+	fns map[string]bool
+}{
+	fns: map[string]bool{},
+}
+
+// Helper marks the calling function as a logging helper, like
+// testing.T.Helper: its frames are skipped when the caller of a message is
+// resolved, so file:line points at the code that called the helper.
+func Helper() {
+	f, ok := callerFrame(1)
+	if !ok {
+		return
+	}
+	helpers.RLock()
+	known := helpers.fns[f.Function]
+	helpers.RUnlock()
+	if known {
+		return
+	}
+	helpers.Lock()
+	helpers.fns[f.Function] = true
+	helpers.Unlock()
+}
+
+func isHelper(function string) bool {
+	helpers.RLock()
+	defer helpers.RUnlock()
+	return helpers.fns[function]
+}
+
+// WithCallerSkip returns a child logger that reports the caller n frames
+// further up the stack, for wrappers that cannot call Helper.
+func (bl *Loguru) WithCallerSkip(n int) *Loguru {
+	child := bl.withFields(nil)
+	child.callerSkip += n
+	return child
+}
+
+// callerFrame is runtime.Caller for the function calling callerFrame, but
+// resolves inlined calls and the function name as well.
+func callerFrame(skip int) (runtime.Frame, bool) {
+	pcs := make([]uintptr, 1)
+	if runtime.Callers(skip+2, pcs) == 0 {
+		return runtime.Frame{}, false
+	}
+	f, _ := runtime.CallersFrames(pcs).Next()
+	return f, true
+}
+
+// resolveCaller returns the first frame above the function calling it that
+// belongs neither to this package nor to a helper, then skips skip more.
+func resolveCaller(skip int) (runtime.Frame, bool) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	it := runtime.CallersFrames(pcs[:n])
+	for more := n > 0; more; {
+		var f runtime.Frame
+		f, more = it.Next()
+		// Frames of the standard library's log package are skipped too, for
+		// lines that arrive through RedirectStdLog or GetLogger.
+		if isSelfFrame(f) || isHelper(f.Function) || funcPackage(f.Function) == "log" {
+			continue
+		}
+		if skip == 0 {
+			return f, true
+		}
+		skip--
+	}
+	return runtime.Frame{}, false
+}
+
+func WithCallerSkip(n int) *Loguru {
+	return std().WithCallerSkip(n)
+}
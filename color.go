@@ -49,19 +49,6 @@ var colorsMap = map[string]brush{
 	"backWhite":   newBrush(BackWHITE),
 }
 
-var colors = []brush{
-	colorsMap["backRed"],   // Emergency          backRed
-	colorsMap["backCyan"],  // Alert              backCyan
-	colorsMap["backBlue"],  // Critical           backBlue
-	colorsMap["red"],       // Error              red
-	colorsMap["yellow"],    // Warning            yellow
-	colorsMap["green"],     // Success            green
-	colorsMap["backGreen"], // Notice             backGreen
-	colorsMap["white"],     // Informational      white
-	colorsMap["fuchsia"],   // Input              fuchsia
-	colorsMap["blue"],      // Debug              blue
-}
-
 var (
 	timeColor = colorsMap["white"]
 	fileColor = colorsMap["white"]
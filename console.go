@@ -7,26 +7,42 @@ import (
 	"github.com/shiena/ansicolor"
 	"os"
 	"strings"
+	"sync/atomic"
 )
 
 type consoleWriter struct {
 	lg        *logWriter
 	formatter LogFormatter
 	Formatter string `json:"formatter"`
-	Level     int    `json:"level"`
+	Level     int32  `json:"level"`
 	Colorful  bool   `json:"color"`
+	callerOptions
 }
 
 func (c *consoleWriter) Format(lm *LogMsg) string {
-	msg := lm.ColorStyleFormat()
-	if c.Colorful {
-		msg = strings.Replace(msg, levelPrefix[lm.Level], colors[lm.Level](levelPrefix[lm.Level]), 1)
-	}
+	return consoleFormat(lm, c.Colorful)
+}
+
+func consoleFormat(lm *LogMsg, colorful bool) string {
 	h, _, _ := formatTimeHeader(lm.When)
+	if !colorful {
+		return string(h) + lm.NormalFormat()
+	}
+	msg := lm.ColorStyleFormat()
+	prefix := levelPrefix(lm.Level)
+	msg = strings.Replace(msg, prefix, levelColor(lm.Level)(prefix), 1)
 	bytes := append(append([]byte(timeColor(string(h))), colorsMap["red"](" |  ")...), msg...)
 	return string(bytes)
 }
 
+func (c *consoleWriter) SetLevel(l int) {
+	atomic.StoreInt32(&c.Level, int32(l))
+}
+
+func (c *consoleWriter) GetLevel() int {
+	return int(atomic.LoadInt32(&c.Level))
+}
+
 func (c *consoleWriter) SetFormatter(f LogFormatter) {
 	c.formatter = f
 }
@@ -59,19 +75,36 @@ func (c *consoleWriter) Init(config string) error {
 		}
 		c.formatter = fmtr
 	}
+	if res == nil {
+		res = c.check()
+	}
 	return res
 }
 
 func (c *consoleWriter) WriteMsg(lm *LogMsg) error {
-	if lm.Level > c.Level {
+	if !levelEnabled(lm.Level, c.GetLevel()) {
+		return nil
+	}
+	c.apply(lm)
+	msg := formatMsg(c.formatter, lm)
+	statusLine.Lock()
+	defer statusLine.Unlock()
+	clearStatus()
+	if lm.Raw {
+		_, _ = c.lg.write(msg)
+		statusLine.paused = !strings.HasSuffix(msg, "\n")
+		drawStatus()
 		return nil
 	}
-	msg := c.formatter.Format(lm)
 	if lm.Level == LevelInput {
+		// Keep the progress bars off the prompt line until the next message.
+		statusLine.paused = true
 		_, _ = c.lg.write(msg)
-	} else {
-		_, _ = c.lg.writeln(msg)
+		return nil
 	}
+	statusLine.paused = false
+	_, _ = c.lg.writeln(msg)
+	drawStatus()
 	return nil
 }
 
@@ -0,0 +1,231 @@
+package loguru
+
+import (
+	"context"
+	"sync"
+)
+
+type loggerCtxKey struct{}
+
+type fieldsCtxKey struct{}
+
+// ContextExtractor pulls fields such as request or trace IDs out of a context.
+type ContextExtractor func(ctx context.Context) []Field
+
+var ctxExtractors = struct {
+	sync.RWMutex
+	names []string
+	fns   map[string]ContextExtractor
+}{
+	fns: map[string]ContextExtractor{},
+}
+
+func RegisterContextExtractor(name string, fn ContextExtractor) {
+	if fn == nil {
+		panic("logs: RegisterContextExtractor provide is nil")
+	}
+	ctxExtractors.Lock()
+	defer ctxExtractors.Unlock()
+	if _, dup := ctxExtractors.fns[name]; !dup {
+		ctxExtractors.names = append(ctxExtractors.names, name)
+	}
+	ctxExtractors.fns[name] = fn
+}
+
+func UnregisterContextExtractor(name string) {
+	ctxExtractors.Lock()
+	defer ctxExtractors.Unlock()
+	if _, ok := ctxExtractors.fns[name]; !ok {
+		return
+	}
+	delete(ctxExtractors.fns, name)
+	for i, n := range ctxExtractors.names {
+		if n == name {
+			ctxExtractors.names = append(ctxExtractors.names[:i:i], ctxExtractors.names[i+1:]...)
+			break
+		}
+	}
+}
+
+// ContextValue returns an extractor that logs ctx.Value(key) under field.
+func ContextValue(key interface{}, field string) ContextExtractor {
+	return func(ctx context.Context) []Field {
+		if v := ctx.Value(key); v != nil {
+			return []Field{{Key: field, Value: v}}
+		}
+		return nil
+	}
+}
+
+func contextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	ctxExtractors.RLock()
+	defer ctxExtractors.RUnlock()
+	var fields []Field
+	for _, name := range ctxExtractors.names {
+		fields = append(fields, ctxExtractors.fns[name](ctx)...)
+	}
+	return fields
+}
+
+func NewContext(ctx context.Context, l *Loguru) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// FromContext returns the logger stored by NewContext, or the global logger.
+func FromContext(ctx context.Context) *Loguru {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerCtxKey{}).(*Loguru); ok && l != nil {
+			return l
+		}
+	}
+	return std()
+}
+
+// ContextWithFields stores keyvals in ctx; they are added to every message
+// logged with that context.
+func ContextWithFields(ctx context.Context, keyvals ...interface{}) context.Context {
+	fields, _ := ctx.Value(fieldsCtxKey{}).([]Field)
+	merged := make([]Field, 0, len(fields)+len(keyvals))
+	merged = append(merged, fields...)
+	merged = append(merged, makeFields(keyvals)...)
+	return context.WithValue(ctx, fieldsCtxKey{}, merged)
+}
+
+func (bl *Loguru) WithContext(ctx context.Context) *Loguru {
+	fields := contextFields(ctx)
+	if len(fields) == 0 {
+		return bl
+	}
+	return bl.withFields(fields)
+}
+
+func (bl *Loguru) EmergencyCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelEmergency) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelEmergency, format, v...)
+}
+
+func (bl *Loguru) AlertCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelAlert) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelAlert, format, v...)
+}
+
+func (bl *Loguru) CriticalCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelCritical) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelCritical, format, v...)
+}
+
+func (bl *Loguru) ErrorCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelError) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelError, format, v...)
+}
+
+func (bl *Loguru) WarningCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelWarn) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelWarn, format, v...)
+}
+
+func (bl *Loguru) WarnCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelWarn) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelWarn, format, v...)
+}
+
+func (bl *Loguru) NoticeCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelNotice) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelNotice, format, v...)
+}
+
+func (bl *Loguru) InfoCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelInfo) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelInfo, format, v...)
+}
+
+func (bl *Loguru) SuccessCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelSuccess) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelSuccess, format, v...)
+}
+
+func (bl *Loguru) DebugCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelDebug) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelDebug, format, v...)
+}
+
+func (bl *Loguru) TraceCtx(ctx context.Context, format string, v ...interface{}) {
+	if !bl.enabled(LevelDebug) {
+		return
+	}
+	_ = bl.WithContext(ctx).writeMsg(LevelDebug, format, v...)
+}
+
+func EmergencyCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).EmergencyCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func AlertCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).AlertCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func CriticalCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).CriticalCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func ErrorCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).ErrorCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func WarningCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).WarnCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func WarnCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).WarnCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func NoticeCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).NoticeCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func InfoCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).InfoCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func SuccessCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).SuccessCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func DebugCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).DebugCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func TraceCtx(ctx context.Context, f interface{}, v ...interface{}) {
+	FromContext(ctx).TraceCtx(ctx, logTemplate(f, v...), v...)
+}
+
+func init() {
+	RegisterContextExtractor("fields", func(ctx context.Context) []Field {
+		fields, _ := ctx.Value(fieldsCtxKey{}).([]Field)
+		return fields
+	})
+}
@@ -0,0 +1,92 @@
+package loguru
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type deduper struct {
+	mu       sync.Mutex
+	window   time.Duration
+	last     *LogMsg
+	lastSeen time.Time
+	repeats  int
+}
+
+// filter reports whether lm should be written. A repeat of the previous
+// message within the window is held back; the first message that breaks a
+// run returns the pending summary to be written before it.
+func (d *deduper) filter(lm *LogMsg) (summary *LogMsg, write bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.last != nil && sameMessage(d.last, lm) && lm.When.Sub(d.lastSeen) < d.window {
+		d.repeats++
+		d.lastSeen = lm.When
+		return nil, false
+	}
+	summary = d.summary()
+	last := *lm
+	d.last = &last
+	d.lastSeen = lm.When
+	d.repeats = 0
+	return summary, true
+}
+
+// sameMessage reports whether b repeats a: the same call site, level, text,
+// logger, fields and error.
+func sameMessage(a, b *LogMsg) bool {
+	if a.Level != b.Level || a.Msg != b.Msg || a.FilePath != b.FilePath || a.LineNumber != b.LineNumber || a.Name != b.Name {
+		return false
+	}
+	if (a.Err == nil) != (b.Err == nil) || a.Err != nil && fmt.Sprint(a.Err) != fmt.Sprint(b.Err) {
+		return false
+	}
+	if len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for i, f := range a.Fields {
+		if f.Key != b.Fields[i].Key || fieldText(f.Value) != fieldText(b.Fields[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *deduper) flush() *LogMsg {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.summary()
+}
+
+func (d *deduper) summary() *LogMsg {
+	if d.repeats == 0 {
+		return nil
+	}
+	summary := *d.last
+	summary.Msg = fmt.Sprintf("last message repeated %d times", d.repeats)
+	summary.format = summary.Msg
+	summary.When = d.lastSeen
+	summary.Err = nil
+	summary.Stack = ""
+	d.repeats = 0
+	return &summary
+}
+
+// SetDedup collapses consecutive repeats of the same level, message, fields
+// and error seen within window into a single "last message repeated N times"
+// line, written when the run ends or on Flush. A zero window turns it off.
+func (bl *Loguru) SetDedup(window time.Duration) {
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	if window <= 0 {
+		bl.dedup = nil
+		return
+	}
+	bl.dedup = &deduper{window: window}
+}
+
+func SetDedup(window time.Duration) {
+	std().SetDedup(window)
+}
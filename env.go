@@ -0,0 +1,191 @@
+package loguru
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// setting is one option of the global logger that can come from an
+// environment variable or a command line flag.
+type setting struct {
+	env    string
+	flag   string
+	usage  string
+	isBool bool
+	apply  func(bl *Loguru, value string) error
+	// fromFlag is set once the flag was given, so that std skips the
+	// environment variable.
+	fromFlag bool
+}
+
+var settings = []*setting{
+	{env: "LOGURU_LEVEL", flag: "log-level", usage: "lowest level logged, such as info or warn", apply: (*Loguru).applyLevel},
+	{env: "LOGURU_FORMAT", flag: "log-format", usage: "registered formatter such as json, or a pattern like \"%w %t %m\"", apply: (*Loguru).applyFormat},
+	{env: "LOGURU_COLOR", flag: "log-color", usage: "colorize console output: true, false or auto", isBool: true, apply: (*Loguru).applyColor},
+	{env: "LOGURU_FILE", flag: "log-file", usage: "also log to this file", apply: (*Loguru).applyFile},
+	{env: "LOGURU_ASYNC", flag: "log-async", usage: "log asynchronously: true, false or a queue length", isBool: true, apply: (*Loguru).applyAsync},
+}
+
+var stdOnce sync.Once
+
+// std returns the global logger, configured from the LOGURU_* environment
+// variables the first time it is used.
+func std() *Loguru {
+	stdOnce.Do(func() {
+		for _, s := range settings {
+			v, ok := os.LookupEnv(s.env)
+			if !ok || v == "" || s.fromFlag {
+				continue
+			}
+			if err := s.apply(logger, v); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "loguru: ignoring %s: %v\n", s.env, err)
+			}
+		}
+	})
+	return logger
+}
+
+func (bl *Loguru) applyLevel(v string) error {
+	l, err := ParseLevel(v)
+	if err != nil {
+		return err
+	}
+	bl.SetLevel(l)
+	return nil
+}
+
+func (bl *Loguru) applyFormat(v string) error {
+	name := v
+	if strings.Contains(v, "%") {
+		name = "env"
+		RegisterFormatter(name, &PatternLogFormatter{Pattern: v})
+	}
+	f, ok := GetFormatter(name)
+	if !ok {
+		return fmt.Errorf("logs: unknown formatter %q", v)
+	}
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	bl.setConsoleOption("formatter", name)
+	for _, lg := range bl.outputs {
+		if lg.name == AdapterConsole || lg.name == AdapterFile {
+			lg.SetFormatter(f)
+		}
+	}
+	return nil
+}
+
+func (bl *Loguru) applyColor(v string) error {
+	color := isTerminal(int(os.Stdout.Fd()))
+	if v != "auto" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		color = b
+	}
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	bl.setConsoleOption("color", color)
+	for _, lg := range bl.outputs {
+		if c, ok := lg.Logger.(*consoleWriter); ok {
+			c.Colorful = color
+		}
+	}
+	return nil
+}
+
+func (bl *Loguru) applyFile(v string) error {
+	bl = bl.root()
+	bl.lock.Lock()
+	config := map[string]interface{}{"filename": v}
+	if name, ok := bl.consoleOptions["formatter"]; ok {
+		config["formatter"] = name
+	}
+	old := bl.fileSink
+	bl.lock.Unlock()
+	b, _ := json.Marshal(config)
+	id, err := bl.Add(AdapterFile, string(b))
+	if err != nil {
+		return err
+	}
+	bl.lock.Lock()
+	bl.fileSink = id
+	bl.lock.Unlock()
+	if old != 0 {
+		return bl.Remove(old)
+	}
+	return nil
+}
+
+func (bl *Loguru) applyAsync(v string) error {
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		if n > 0 {
+			bl.Async(n)
+		}
+		return nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return err
+	}
+	if b {
+		bl.Async()
+	}
+	return nil
+}
+
+// setConsoleOption changes the config of the console adapter that Console
+// mode adds on first use. bl.lock must be held.
+func (bl *Loguru) setConsoleOption(key string, value interface{}) {
+	if bl.consoleOptions == nil {
+		bl.consoleOptions = map[string]interface{}{}
+	}
+	bl.consoleOptions[key] = value
+	b, _ := json.Marshal(bl.consoleOptions)
+	bl.consoleConfig = string(b)
+}
+
+type settingFlag struct {
+	s     *setting
+	value string
+}
+
+func (f *settingFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+// Set applies v to the global logger without loading the environment, so
+// that a flag replaces its LOGURU_* variable instead of being applied on top.
+func (f *settingFlag) Set(v string) error {
+	if err := f.s.apply(logger, v); err != nil {
+		return err
+	}
+	f.s.fromFlag = true
+	f.value = v
+	return nil
+}
+
+func (f *settingFlag) IsBoolFlag() bool {
+	return f.s.isBool
+}
+
+// RegisterFlags adds -log-level, -log-format, -log-color, -log-file and
+// -log-async to fs. They configure the global logger when fs is parsed and
+// replace the LOGURU_* environment variables, as long as fs is parsed before
+// the global logger is first used.
+func RegisterFlags(fs *flag.FlagSet) {
+	for _, s := range settings {
+		fs.Var(&settingFlag{s: s}, s.flag, s.usage+" (env "+s.env+")")
+	}
+}
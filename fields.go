@@ -0,0 +1,109 @@
+package loguru
+
+import (
+	"fmt"
+	"strings"
+)
+
+const badKey = "!BADKEY"
+
+// Field is a structured key-value pair carried by every LogMsg written
+// through a logger created with With.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+func (f Field) String() string {
+	return f.Key + "=" + formatFieldValue(f.Value)
+}
+
+// With returns a child logger that attaches keyvals to every message. The
+// child shares outputs, level and async queue with bl; its fields never
+// leak back to bl.
+func (bl *Loguru) With(keyvals ...interface{}) *Loguru {
+	if len(keyvals) == 0 {
+		return bl
+	}
+	return bl.withFields(makeFields(keyvals))
+}
+
+func (bl *Loguru) withFields(fields []Field) *Loguru {
+	merged := make([]Field, 0, len(bl.fields)+len(fields))
+	merged = append(merged, bl.fields...)
+	merged = append(merged, fields...)
+	return &Loguru{parent: bl, fields: merged, name: bl.name, err: bl.err, callerSkip: bl.callerSkip, scope: bl.scope, opts: bl.opts}
+}
+
+func (bl *Loguru) Fields() []Field {
+	return append([]Field(nil), bl.fields...)
+}
+
+func makeFields(keyvals []interface{}) []Field {
+	fields := make([]Field, 0, (len(keyvals)+1)/2)
+	for i := 0; i < len(keyvals); i++ {
+		switch k := keyvals[i].(type) {
+		case Field:
+			fields = append(fields, k)
+		case []Field:
+			fields = append(fields, k...)
+		case string:
+			if i+1 == len(keyvals) {
+				fields = append(fields, Field{Key: badKey, Value: k})
+				continue
+			}
+			fields = append(fields, Field{Key: k, Value: keyvals[i+1]})
+			i++
+		default:
+			if i+1 == len(keyvals) {
+				fields = append(fields, Field{Key: badKey, Value: k})
+				continue
+			}
+			fields = append(fields, Field{Key: fmt.Sprint(k), Value: keyvals[i+1]})
+			i++
+		}
+	}
+	return fields
+}
+
+// fieldText is the unquoted text of a field value.
+func fieldText(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case []byte:
+		return string(t)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatFieldValue(v interface{}) string {
+	s := fieldText(v)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+func formatFields(fields []Field, key brush) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, f := range fields {
+		b.WriteByte(' ')
+		if key != nil {
+			b.WriteString(key(f.Key))
+		} else {
+			b.WriteString(f.Key)
+		}
+		b.WriteByte('=')
+		b.WriteString(formatFieldValue(f.Value))
+	}
+	return b.String()
+}
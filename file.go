@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -41,7 +42,7 @@ type fileLogWriter struct {
 
 	Rotate bool `json:"rotate"`
 
-	Level int `json:"level"`
+	Level int32 `json:"level"`
 
 	Perm string `json:"perm"`
 
@@ -51,6 +52,7 @@ type fileLogWriter struct {
 
 	formatter LogFormatter
 	Formatter string `json:"formatter"`
+	callerOptions
 }
 
 func newFileWriter() Logger {
@@ -78,6 +80,14 @@ func (w *fileLogWriter) Format(lm *LogMsg) string {
 	return msg
 }
 
+func (w *fileLogWriter) SetLevel(l int) {
+	atomic.StoreInt32(&w.Level, int32(l))
+}
+
+func (w *fileLogWriter) GetLevel() int {
+	return int(atomic.LoadInt32(&w.Level))
+}
+
 func (w *fileLogWriter) SetFormatter(f LogFormatter) {
 	w.formatter = f
 }
@@ -90,6 +100,9 @@ func (w *fileLogWriter) Init(config string) error {
 	if len(w.Filename) == 0 {
 		return errors.New("json config must have filename")
 	}
+	if err := w.check(); err != nil {
+		return err
+	}
 	w.suffix = filepath.Ext(w.Filename)
 	w.fileNameOnly = strings.TrimSuffix(w.Filename, w.suffix)
 	if w.suffix == "" {
@@ -133,13 +146,17 @@ func (w *fileLogWriter) needRotateHourly(hour int) bool {
 }
 
 func (w *fileLogWriter) WriteMsg(lm *LogMsg) error {
-	if lm.Level > w.Level {
+	if !levelEnabled(lm.Level, w.GetLevel()) {
 		return nil
 	}
 
 	_, d, h := formatTimeHeader(lm.When)
 
-	msg := w.formatter.Format(lm)
+	w.apply(lm)
+	msg := formatMsg(w.formatter, lm)
+	if !lm.Raw && !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
 	if w.Rotate {
 		w.RLock()
 		if w.needRotateHourly(h) {
@@ -1,8 +1,12 @@
 package loguru
 
 import (
+	"encoding/json"
+	"fmt"
 	"path"
 	"strconv"
+	"strings"
+	"time"
 )
 
 var formatterMap = make(map[string]LogFormatter, 4)
@@ -44,9 +48,19 @@ func (p *PatternLogFormatter) ToString(lm *LogMsg) string {
 		'm': lm.Msg,
 		'n': strconv.Itoa(lm.LineNumber),
 		'l': strconv.Itoa(lm.Level),
-		't': levelPrefix[lm.Level-1],
-		'T': levelNames[lm.Level-1],
+		't': levelPrefix(lm.Level),
+		'T': LevelName(lm.Level),
 		'F': lm.FilePath,
+		'c': shortFuncName(lm.FuncName),
+		'P': lm.Package,
+		'N': lm.Name,
+		's': lm.Stack,
+		'e': "",
+		'S': lm.Scope,
+		'k': strings.TrimPrefix(formatFields(lm.Fields, nil), " "),
+	}
+	if lm.Err != nil {
+		m['e'] = fmt.Sprint(lm.Err)
 	}
 	_, m['f'] = path.Split(lm.FilePath)
 	res := ""
@@ -62,3 +76,78 @@ func (p *PatternLogFormatter) ToString(lm *LogMsg) string {
 	}
 	return res
 }
+
+// JSONLogFormatter renders each message as one JSON object, with the caller
+// under "caller" as separate file, line, function and package keys.
+type JSONLogFormatter struct {
+	WhenFormat string
+}
+
+func (j *JSONLogFormatter) Format(lm *LogMsg) string {
+	whenFormat := j.WhenFormat
+	if whenFormat == "" {
+		whenFormat = time.RFC3339Nano
+	}
+	msg := lm.Msg
+	if len(lm.Args) > 0 {
+		msg = fmt.Sprintf(msg, lm.Args...)
+	}
+	entry := map[string]interface{}{
+		"time":  lm.When.Format(whenFormat),
+		"level": LevelName(lm.Level),
+		"msg":   msg,
+	}
+	if lm.Name != "" {
+		entry["logger"] = lm.Name
+	}
+	if lm.enableFuncCallDepth && lm.FilePath != "" {
+		file := lm.FilePath
+		if !lm.enableFullFilePath {
+			_, file = path.Split(file)
+		}
+		entry["caller"] = map[string]interface{}{
+			"file":     file,
+			"line":     lm.LineNumber,
+			"function": lm.FuncName,
+			"package":  lm.Package,
+		}
+	}
+	if len(lm.Fields) > 0 {
+		fields := make(map[string]interface{}, len(lm.Fields))
+		for _, f := range lm.Fields {
+			fields[f.Key] = jsonValue(f.Value)
+		}
+		entry["fields"] = fields
+	}
+	if lm.Scope != "" {
+		entry["scope"] = lm.Scope
+		entry["scope_elapsed"] = lm.ScopeElapsed.String()
+	}
+	if lm.Err != nil {
+		entry["error"] = fmt.Sprint(lm.Err)
+	}
+	if lm.Stack != "" {
+		entry["stack"] = lm.Stack
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Sprintf(`{"level":%q,"msg":%q,"error":%q}`, LevelName(lm.Level), msg, err.Error())
+	}
+	return string(b)
+}
+
+// jsonValue keeps field values that encode as JSON and falls back to their
+// text form for the rest, such as errors and channels.
+func jsonValue(v interface{}) interface{} {
+	if err, ok := v.(error); ok {
+		return fmt.Sprint(err)
+	}
+	if _, err := json.Marshal(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return v
+}
+
+func init() {
+	RegisterFormatter("json", &JSONLogFormatter{})
+}
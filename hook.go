@@ -0,0 +1,61 @@
+package loguru
+
+// Hook runs on every message logged through the Loguru it was added to,
+// before the message reaches the outputs. It may change lm, including its
+// Fields, and returns false to drop it.
+type Hook interface {
+	Fire(lm *LogMsg) bool
+}
+
+type HookFunc func(lm *LogMsg) bool
+
+func (f HookFunc) Fire(lm *LogMsg) bool {
+	return f(lm)
+}
+
+// FieldsHook returns a hook that appends keyvals to every message.
+func FieldsHook(keyvals ...interface{}) Hook {
+	fields := makeFields(keyvals)
+	return HookFunc(func(lm *LogMsg) bool {
+		lm.Fields = append(lm.Fields, fields...)
+		return true
+	})
+}
+
+// AddHook appends h to the hooks of bl. Hooks of a parent logger run before
+// those of its children, each in the order they were added.
+func (bl *Loguru) AddHook(h Hook) {
+	if h == nil {
+		panic("logs: AddHook provide is nil")
+	}
+	bl.lock.Lock()
+	bl.hooks = append(bl.hooks, h)
+	bl.lock.Unlock()
+}
+
+func (bl *Loguru) runHooks(lm *LogMsg) bool {
+	var chain [][]Hook
+	for l := bl; l != nil; l = l.parent {
+		l.lock.Lock()
+		if len(l.hooks) > 0 {
+			chain = append(chain, l.hooks)
+		}
+		l.lock.Unlock()
+	}
+	if len(chain) == 0 {
+		return true
+	}
+	lm.Fields = append([]Field(nil), lm.Fields...)
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, h := range chain[i] {
+			if !h.Fire(lm) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func AddHook(h Hook) {
+	std().AddHook(h)
+}
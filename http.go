@@ -0,0 +1,117 @@
+package loguru
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type levelState struct {
+	Level   string            `json:"level"`
+	Logger  string            `json:"logger,omitempty"`
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+type levelRequest struct {
+	Level  string `json:"level"`
+	Output string `json:"output"`
+	Logger string `json:"logger"`
+}
+
+type levelHandler struct {
+	l *Loguru
+}
+
+// LevelHandler reports the level of l and its outputs on GET and changes
+// them on PUT or POST. The new level is read from a JSON body such as
+// {"level": "warn", "output": "file"} or from the query string; "logger"
+// selects a named logger instead of l.
+func LevelHandler(l *Loguru) http.Handler {
+	return &levelHandler{l: l}
+}
+
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		target := h.l
+		if name := r.URL.Query().Get("logger"); name != "" {
+			var ok bool
+			if target, ok = h.l.lookupNamed(name); !ok {
+				http.Error(w, fmt.Sprintf("logs: unknown logger %q", name), http.StatusNotFound)
+				return
+			}
+		}
+		h.writeState(w, target)
+	case http.MethodPut, http.MethodPost:
+		var req levelRequest
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		} else {
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			req.Level = r.Form.Get("level")
+			req.Output = r.Form.Get("output")
+			req.Logger = r.Form.Get("logger")
+		}
+		level, err := ParseLevel(req.Level)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		target := h.l
+		if req.Logger != "" {
+			target = h.l.namedNode(req.Logger)
+		}
+		if req.Output != "" {
+			old, ok := h.l.AdapterLevels()[req.Output]
+			if err := h.l.SetAdapterLevel(req.Output, level); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if ok && old != level {
+				h.l.logLevelChange(fmt.Sprintf("output %s level changed from %s to %s via http", req.Output, LevelName(old), LevelName(level)))
+			}
+		} else {
+			target.changeLevel(level, "http")
+		}
+		h.writeState(w, target)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *levelHandler) writeState(w http.ResponseWriter, target *Loguru) {
+	state := levelState{Level: LevelName(target.GetLevel()), Logger: target.name}
+	if levels := h.l.AdapterLevels(); len(levels) > 0 {
+		state.Outputs = make(map[string]string, len(levels))
+		for name, l := range levels {
+			state.Outputs[name] = LevelName(l)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state)
+}
+
+func (bl *Loguru) changeLevel(level int, source string) {
+	old := bl.GetLevel()
+	bl.SetLevel(level)
+	if old == level {
+		return
+	}
+	name := ""
+	if bl.name != "" {
+		name = bl.name + " "
+	}
+	bl.logLevelChange(fmt.Sprintf("%slog level changed from %s to %s via %s", name, LevelName(old), LevelName(level), source))
+}
+
+func (bl *Loguru) logLevelChange(msg string) {
+	_ = bl.writeMsg(LevelNotice, msg)
+}
@@ -0,0 +1,185 @@
+package loguru
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// input is where the Input functions read from. A file such as os.Stdin is
+// read a byte at a time, so that nothing past the answer is taken from it;
+// other readers are buffered in r.
+var input = struct {
+	sync.Mutex
+	r    *bufio.Reader
+	file *os.File
+}{
+	file: os.Stdin,
+}
+
+// SetInput makes the Input functions read from r instead of os.Stdin.
+func SetInput(r io.Reader) {
+	input.Lock()
+	defer input.Unlock()
+	input.file, _ = r.(*os.File)
+	input.r = nil
+	if input.file == nil {
+		input.r = bufio.NewReader(r)
+	}
+}
+
+func readLine() (string, error) {
+	input.Lock()
+	defer input.Unlock()
+	return readLineLocked()
+}
+
+func readLineLocked() (string, error) {
+	var line string
+	var err error
+	if input.r != nil {
+		line, err = input.r.ReadString('\n')
+	} else {
+		line, err = readFileLine(input.file)
+	}
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+func readFileLine(f *os.File) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := f.Read(b)
+		if n > 0 {
+			line = append(line, b[0])
+			if b[0] == '\n' {
+				return string(line), nil
+			}
+		}
+		if err != nil {
+			return string(line), err
+		}
+	}
+}
+
+// prompt shows s at LevelInput and makes sure it is on screen before input
+// is read.
+func (bl *Loguru) prompt(s string) {
+	_ = bl.writeMsg(LevelInput, s)
+	if bl.root().asynchronous {
+		bl.Flush()
+	}
+}
+
+// InputLine prompts and reads a whole line, spaces included.
+func (bl *Loguru) InputLine(prompt string) (string, error) {
+	bl.prompt(prompt)
+	return readLine()
+}
+
+// InputPassword prompts and reads a line without echoing it when the input
+// is a terminal.
+func (bl *Loguru) InputPassword(prompt string) (string, error) {
+	bl.prompt(prompt)
+	input.Lock()
+	defer input.Unlock()
+	if input.file == nil || !isTerminal(int(input.file.Fd())) {
+		return readLineLocked()
+	}
+	var line string
+	var err error
+	if echoErr := withEchoOff(int(input.file.Fd()), func() { line, err = readLineLocked() }); echoErr != nil {
+		return "", echoErr
+	}
+	fmt.Println()
+	return line, err
+}
+
+// Confirm asks a yes/no question and reports whether it was answered yes.
+// An empty answer means no.
+func (bl *Loguru) Confirm(prompt string) (bool, error) {
+	for {
+		answer, err := bl.InputLine(prompt + " [y/N]: ")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true, nil
+		case "", "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// Choose lists options and returns the index of the one picked, by number
+// or by name.
+func (bl *Loguru) Choose(prompt string, options []string) (int, error) {
+	if len(options) == 0 {
+		return -1, errors.New("logs: Choose called without options")
+	}
+	for i, o := range options {
+		_ = bl.writeMsg(LevelInfo, fmt.Sprintf("  %d) %s", i+1, o))
+	}
+	for {
+		answer, err := bl.InputLine(fmt.Sprintf("%s [1-%d]: ", prompt, len(options)))
+		if err != nil {
+			return -1, err
+		}
+		answer = strings.TrimSpace(answer)
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		for i, o := range options {
+			if strings.EqualFold(answer, o) {
+				return i, nil
+			}
+		}
+	}
+}
+
+// InputValidated prompts until validate accepts the answer, at most
+// retries+1 times. Each rejection is logged at LevelWarn; the last one is
+// returned when the attempts run out.
+func (bl *Loguru) InputValidated(prompt string, validate func(string) error, retries int) (string, error) {
+	var err error
+	for i := 0; i <= retries; i++ {
+		var answer string
+		if answer, err = bl.InputLine(prompt); err != nil {
+			return "", err
+		}
+		if err = validate(answer); err == nil {
+			return answer, nil
+		}
+		_ = bl.writeMsg(LevelWarn, err.Error())
+	}
+	return "", err
+}
+
+func InputLine(prompt string) (string, error) {
+	return std().InputLine(prompt)
+}
+
+func InputPassword(prompt string) (string, error) {
+	return std().InputPassword(prompt)
+}
+
+func Confirm(prompt string) (bool, error) {
+	return std().Confirm(prompt)
+}
+
+func Choose(prompt string, options []string) (int, error) {
+	return std().Choose(prompt, options)
+}
+
+func InputValidated(prompt string, validate func(string) error, retries int) (string, error) {
+	return std().InputValidated(prompt, validate, retries)
+}
@@ -0,0 +1,172 @@
+package loguru
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type levelInfo struct {
+	prefix   string
+	severity int
+	color    brush
+}
+
+// levelTable is indexed by level value. The built-in levels use their own
+// value as severity; custom levels get the next free value and the severity
+// they were registered with.
+var levelTable = struct {
+	sync.RWMutex
+	levels   []levelInfo
+	maxWidth int
+}{
+	levels: []levelInfo{
+		{"EMERGENCY", LevelEmergency, colorsMap["backRed"]},
+		{"ALERT", LevelAlert, colorsMap["backCyan"]},
+		{"CRITICAL", LevelCritical, colorsMap["backBlue"]},
+		{"ERROR", LevelError, colorsMap["red"]},
+		{"WARNING", LevelWarning, colorsMap["yellow"]},
+		{"SUCCESS", LevelSuccess, colorsMap["green"]},
+		{"NOTICE", LevelNotice, colorsMap["backGreen"]},
+		{"INFO", LevelInformational, colorsMap["white"]},
+		{"INPUT", LevelInput, colorsMap["fuchsia"]},
+		{"DEBUG", LevelDebug, colorsMap["blue"]},
+		{"FATAL", LevelEmergency, colorsMap["backRed"]},
+	},
+	maxWidth: len("EMERGENCY"),
+}
+
+var levelAliases = map[string]int{
+	"emerg":         LevelEmergency,
+	"crit":          LevelCritical,
+	"err":           LevelError,
+	"warn":          LevelWarn,
+	"informational": LevelInformational,
+	"trace":         LevelTrace,
+}
+
+func colorBrush(color string) brush {
+	if b, ok := colorsMap[color]; ok {
+		return b
+	}
+	if color == "" {
+		return colorsMap["white"]
+	}
+	return newBrush(color)
+}
+
+// RegisterLevel adds a level such as "AUDIT" that is enabled whenever a
+// built-in level of the same severity is. color is either a color code like
+// CYAN or a name like "cyan". The returned value can be passed to Log,
+// SetLevel and the adapters' level settings.
+func RegisterLevel(name string, severity int, color string) int {
+	prefix := strings.ToUpper(strings.TrimSpace(name))
+	if prefix == "" {
+		panic("logs: RegisterLevel called with an empty name")
+	}
+	levelTable.Lock()
+	defer levelTable.Unlock()
+	for _, info := range levelTable.levels {
+		if info.prefix == prefix {
+			panic("logs: RegisterLevel called twice for level " + prefix)
+		}
+	}
+	if _, dup := levelAliases[strings.ToLower(prefix)]; dup {
+		panic("logs: RegisterLevel called twice for level " + prefix)
+	}
+	levelTable.levels = append(levelTable.levels, levelInfo{prefix, severity, colorBrush(color)})
+	if len(prefix) > levelTable.maxWidth {
+		levelTable.maxWidth = len(prefix)
+	}
+	return len(levelTable.levels) - 1
+}
+
+func lookupLevel(l int) (levelInfo, bool) {
+	levelTable.RLock()
+	defer levelTable.RUnlock()
+	if l < 0 || l >= len(levelTable.levels) {
+		return levelInfo{}, false
+	}
+	return levelTable.levels[l], true
+}
+
+func levelSeverity(l int) int {
+	if info, ok := lookupLevel(l); ok {
+		return info.severity
+	}
+	return l
+}
+
+// levelEnabled reports whether a message at level passes a threshold.
+func levelEnabled(level, threshold int) bool {
+	return levelSeverity(level) <= levelSeverity(threshold)
+}
+
+func levelPrefix(l int) string {
+	if info, ok := lookupLevel(l); ok {
+		return info.prefix
+	}
+	return strconv.Itoa(l)
+}
+
+func levelColor(l int) brush {
+	if info, ok := lookupLevel(l); ok {
+		return info.color
+	}
+	return colorsMap["white"]
+}
+
+func levelPadding(l int) string {
+	levelTable.RLock()
+	width := levelTable.maxWidth
+	levelTable.RUnlock()
+	if n := width - len(levelPrefix(l)); n > 0 {
+		return strings.Repeat(" ", n)
+	}
+	return ""
+}
+
+func levels() []int {
+	levelTable.RLock()
+	defer levelTable.RUnlock()
+	ls := make([]int, len(levelTable.levels))
+	for i := range ls {
+		ls[i] = i
+	}
+	return ls
+}
+
+func SetColor(level int, color string) {
+	levelTable.Lock()
+	defer levelTable.Unlock()
+	if level >= 0 && level < len(levelTable.levels) {
+		levelTable.levels[level].color = newBrush(color)
+	}
+}
+
+// ParseLevel converts a level name such as "warn", "DEBUG" or a registered
+// custom level, or its numeric value, into a level.
+func ParseLevel(s string) (int, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	levelTable.RLock()
+	for l, info := range levelTable.levels {
+		if info.prefix == name {
+			levelTable.RUnlock()
+			return l, nil
+		}
+	}
+	n := len(levelTable.levels)
+	levelTable.RUnlock()
+	if l, ok := levelAliases[strings.ToLower(name)]; ok {
+		return l, nil
+	}
+	if l, err := strconv.Atoi(name); err == nil && l >= 0 && l < n {
+		return l, nil
+	}
+	return 0, fmt.Errorf("logs: unknown level %q", s)
+}
+
+func LevelName(l int) string {
+	return strings.ToLower(levelPrefix(l))
+}
@@ -0,0 +1,104 @@
+package loguru
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+// maxWriterLine is how much of an unterminated line a Writer buffers before
+// logging it anyway.
+const maxWriterLine = 64 << 10
+
+var errWriterClosed = errors.New("logs: write to closed Writer")
+
+type WriterOption func(w *lineWriter)
+
+// WriterPrefix puts prefix in front of every line.
+func WriterPrefix(prefix string) WriterOption {
+	return func(w *lineWriter) {
+		w.prefix = prefix
+	}
+}
+
+// WriterField adds key=value to every line.
+func WriterField(key string, value interface{}) WriterOption {
+	return func(w *lineWriter) {
+		w.l = w.l.With(key, value)
+	}
+}
+
+type lineWriter struct {
+	mu     sync.Mutex
+	l      *Loguru
+	level  int
+	prefix string
+	buf    []byte
+	closed bool
+}
+
+// Writer returns an io.WriteCloser, for exec.Cmd.Stdout or a library's log
+// output, that logs each line written to it at level. Close logs what is
+// left of an unterminated last line. Lines report the code that called
+// Writer as their caller, since whatever writes them is rarely of interest.
+func (bl *Loguru) Writer(level int, opts ...WriterOption) io.WriteCloser {
+	w := &lineWriter{l: bl, level: level}
+	if f, ok := resolveCaller(bl.callerSkip); ok {
+		w.l = bl.withFields(nil)
+		w.l.opts.frame = &f
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, errWriterClosed
+	}
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	if len(w.buf) >= maxWriterLine {
+		w.logLine(w.buf)
+		w.buf = w.buf[:0]
+	}
+	return len(p), nil
+}
+
+func (w *lineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	if len(w.buf) > 0 {
+		w.logLine(w.buf)
+	}
+	w.buf = nil
+	w.closed = true
+	return nil
+}
+
+func (w *lineWriter) logLine(line []byte) {
+	s := strings.TrimSuffix(string(line), "\r")
+	if s == "" || !w.l.enabled(w.level) {
+		return
+	}
+	_ = w.l.writeMsg(w.level, w.prefix+s)
+}
+
+func Writer(level int, opts ...WriterOption) io.WriteCloser {
+	return std().Writer(level, opts...)
+}
@@ -7,11 +7,10 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
-	"path"
 	"runtime"
-	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -28,8 +27,6 @@ const (
 	LevelDebug
 )
 
-const levelLoggerImpl = -1
-
 const (
 	AdapterConsole = "console"
 	AdapterFile    = "file"
@@ -60,9 +57,13 @@ type Logger interface {
 	SetFormatter(f LogFormatter)
 }
 
+type LeveledLogger interface {
+	Logger
+	SetLevel(l int)
+	GetLevel() int
+}
+
 var adapters = make(map[string]newLoggerFunc)
-var levelPrefix = [LevelDebug + 1]string{"EMERGENCY", "ALERT", "CRITICAL", "ERROR", "WARNING", "SUCCESS", "NOTICE", "INFO", "INPUT", "DEBUG"}
-var levelNames = [...]string{"emergency", "alert", "critical", "error", "warning", "notice", "info", "debug", "input", "success"}
 
 func Register(name string, log newLoggerFunc) {
 	if log == nil {
@@ -75,27 +76,56 @@ func Register(name string, log newLoggerFunc) {
 }
 
 type Loguru struct {
+	counters            asyncCounters
 	space               int
 	lock                sync.Mutex
-	level               int
+	level               int32
 	init                bool
 	mode                int
 	enableFuncCallDepth bool
 	loggerFuncCallDepth int
+	funcCallDepthSet    bool
+	callerSkip          int
 	asynchronous        bool
+	closed              bool
+	dropMu              sync.Mutex
 	prefix              string
 	msgChanLen          int64
 	msgChan             chan *LogMsg
 	signalChan          chan string
 	wg                  sync.WaitGroup
 	outputs             []*nameLogger
+	parent              *Loguru
+	levelSet            int32
+	fields              []Field
+	name                string
+	named               map[string]*Loguru
+	sampler             *sampler
+	dedup               *deduper
+	overflow            OverflowPolicy
+	overflowTimeout     time.Duration
+	neverDropLevel      int
+	err                 error
+	stackLevel          int
+	repanic             bool
+	hooks               []Hook
+	redactor            *Redactor
+	opts                callOptions
+	scope               *scope
+	consoleOptions      map[string]interface{}
+	consoleConfig       string
+	fileSink            SinkID
+	lastSink            SinkID
 }
 
 const defaultAsyncMsgLen = 1e3
 
 type nameLogger struct {
 	Logger
-	name string
+	id       SinkID
+	name     string
+	sampler  *sampler
+	redactor *Redactor
 }
 
 var logMsgPool *sync.Pool
@@ -112,13 +142,26 @@ func NewLogger(mode int, channelLens ...int64) *Loguru {
 	}
 	bl.signalChan = make(chan string, 1)
 	bl.space = 18
+	bl.overflowTimeout = defaultOverflowTimeout
+	bl.stackLevel = -1
+	return bl
+}
+
+func (bl *Loguru) root() *Loguru {
+	for bl.parent != nil {
+		bl = bl.parent
+	}
 	return bl
 }
 
 func (bl *Loguru) Async(msgLen ...int64) *Loguru {
+	if bl.parent != nil {
+		bl.root().Async(msgLen...)
+		return bl
+	}
 	bl.lock.Lock()
 	defer bl.lock.Unlock()
-	if bl.asynchronous {
+	if bl.asynchronous || bl.closed {
 		return bl
 	}
 	bl.asynchronous = true
@@ -155,11 +198,12 @@ func (bl *Loguru) setLogger(adapterName string, configs ...string) error {
 		_, _ = fmt.Fprintln(os.Stderr, "loguru.SetLogger: "+err.Error())
 		return err
 	}
-	bl.outputs = append(bl.outputs, &nameLogger{name: adapterName, Logger: lg})
+	bl.addOutput(adapterName, lg)
 	return nil
 }
 
 func (bl *Loguru) SetLogger(adapterName string, configs ...string) error {
+	bl = bl.root()
 	bl.lock.Lock()
 	defer bl.lock.Unlock()
 	if !bl.init {
@@ -169,7 +213,30 @@ func (bl *Loguru) SetLogger(adapterName string, configs ...string) error {
 	return bl.setLogger(adapterName, configs...)
 }
 
+// Attach adds lg, an adapter that is already initialized such as a capture
+// sink in tests, as an output named name.
+func (bl *Loguru) Attach(name string, lg Logger) error {
+	if lg == nil {
+		return fmt.Errorf("logs: Attach %q provide is nil", name)
+	}
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	if !bl.init {
+		bl.outputs = []*nameLogger{}
+		bl.init = true
+	}
+	for _, l := range bl.outputs {
+		if l.name == name {
+			return fmt.Errorf("logs: duplicate adaptername %q (you have set this logger before)", name)
+		}
+	}
+	bl.addOutput(name, lg)
+	return nil
+}
+
 func (bl *Loguru) DelLogger(adapterName string) error {
+	bl = bl.root()
 	bl.lock.Lock()
 	defer bl.lock.Unlock()
 	var outputs []*nameLogger
@@ -187,103 +254,184 @@ func (bl *Loguru) DelLogger(adapterName string) error {
 	return nil
 }
 
-func (bl *Loguru) writeToLoggers(when time.Time, msg string, level int) {
+func (bl *Loguru) SetAdapterLevel(adapterName string, l int) error {
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	found := false
+	for _, lg := range bl.outputs {
+		if lg.name != adapterName {
+			continue
+		}
+		ll, ok := lg.Logger.(LeveledLogger)
+		if !ok {
+			return fmt.Errorf("logs: adapter %q has no level", adapterName)
+		}
+		ll.SetLevel(l)
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("logs: unknown adaptername %q (forgotten Register?)", adapterName)
+	}
+	return nil
+}
+
+func (bl *Loguru) AdapterLevels() map[string]int {
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	levels := make(map[string]int, len(bl.outputs))
+	for _, lg := range bl.outputs {
+		if ll, ok := lg.Logger.(LeveledLogger); ok {
+			levels[lg.name] = ll.GetLevel()
+		}
+	}
+	return levels
+}
+
+func (bl *Loguru) writeToLoggers(lm *LogMsg) {
+	if d := bl.dedup; d != nil {
+		summary, ok := d.filter(lm)
+		if summary != nil {
+			bl.writeOutputs(summary)
+		}
+		if !ok {
+			return
+		}
+	}
+	bl.writeOutputs(lm)
+}
+
+func (bl *Loguru) writeOutputs(lm *LogMsg) {
 	for _, l := range bl.outputs {
-		err := l.WriteMsg(&LogMsg{
-			Space: bl.space,
-			When:  when,
-			Msg:   msg,
-			Level: level,
-		})
+		m := *lm
+		m.Space = bl.space
+		if l.redactor != nil {
+			l.redactor.Redact(&m)
+		}
+		if l.sampler != nil && !l.sampler.allow(&m) {
+			continue
+		}
+		err := l.WriteMsg(&m)
 		if err != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "unable to WriteMsg to adapter:%v,error:%v\n", l.name, err)
 		}
 	}
 }
 
+// Write logs each line of p at LevelInfo, or at the level given by a marker
+// such as "[ERROR]" at its start, so a *log.Logger can write to bl.
 func (bl *Loguru) Write(p []byte) (n int, err error) {
-	if len(p) == 0 {
-		return 0, nil
+	return bl.writeStd(p, LevelInfo)
+}
+
+func (bl *Loguru) writeMsg(logLevel int, msg string, v ...interface{}) error {
+	format := msg
+	if bl.opts.lazy {
+		v = evalLazy(v)
 	}
-	if p[len(p)-1] == '\n' {
-		p = p[0 : len(p)-1]
+	if len(v) > 0 {
+		msg = fmt.Sprintf(msg, v...)
 	}
-	err = bl.writeMsg(levelLoggerImpl, string(p))
-	if err == nil {
-		return len(p), err
+
+	r := bl.root()
+	lm := &LogMsg{Level: logLevel, Msg: msg, When: time.Now(), Fields: bl.fields, Name: bl.name, Err: bl.err, Raw: bl.opts.raw, format: format}
+	if r.enableFuncCallDepth {
+		var f runtime.Frame
+		var ok bool
+		if bl.opts.frame != nil {
+			f, ok = *bl.opts.frame, true
+		} else if r.funcCallDepthSet {
+			f, ok = callerFrame(r.loggerFuncCallDepth + bl.callerSkip)
+		} else {
+			f, ok = resolveCaller(bl.callerSkip)
+		}
+		if !ok {
+			f = runtime.Frame{File: "???"}
+		}
+		lm.setCaller(f)
+	}
+	if bl.scope != nil {
+		lm.Scope, lm.ScopeDepth, lm.ScopeElapsed = bl.scope.path, bl.scope.depth, time.Since(bl.scope.started)
+	}
+	if bl.err != nil {
+		lm.Stack = errorStack(bl.err)
+	}
+	if lm.Stack == "" && (bl.opts.stack || levelEnabled(logLevel, r.stackLevel)) {
+		lm.Stack = callerStack()
 	}
-	return 0, err
+	bl.emit(lm)
+	return nil
 }
 
-func (bl *Loguru) writeMsg(logLevel int, msg string, v ...interface{}) error {
-	bl.lock.Lock()
-	switch bl.mode {
+func (bl *Loguru) emit(lm *LogMsg) {
+	if !bl.runHooks(lm) {
+		return
+	}
+	bl.redact(lm)
+	if !bl.sampled(lm) {
+		return
+	}
+	r := bl.root()
+	r.lock.Lock()
+	switch r.mode {
 	case Console:
-		_ = bl.setLogger(AdapterConsole)
+		_ = r.setLogger(AdapterConsole, r.consoleConfig)
 	case FileLog:
 		executePath, _ := os.Getwd()
 		configBytes, _ := ioutil.ReadFile(executePath + "/logs/file.json")
-		_ = bl.setLogger(AdapterFile, string(configBytes))
+		_ = r.setLogger(AdapterFile, string(configBytes))
 	case OnlineLog:
 		executePath, _ := os.Getwd()
 		configBytes, _ := ioutil.ReadFile(executePath + "/logs/online.json")
-		_ = bl.setLogger(AdapterOnline, string(configBytes))
-	}
-	bl.lock.Unlock()
-
-	if len(v) > 0 {
-		msg = fmt.Sprintf(msg, v...)
-	}
-
-	when := time.Now()
-	if bl.enableFuncCallDepth {
-		_, file, line, ok := runtime.Caller(bl.loggerFuncCallDepth)
-		if !ok {
-			file = "???"
-			line = 0
-		}
-		_, filename := path.Split(file)
-		msg = "[" + filename + ":" + strconv.Itoa(line) + "] " + msg
+		_ = r.setLogger(AdapterOnline, string(configBytes))
 	}
+	async := r.asynchronous && !r.closed
+	r.lock.Unlock()
 
-	if bl.asynchronous {
-		lm := logMsgPool.Get().(*LogMsg)
-		lm.Level = logLevel
-		lm.Msg = msg
-		lm.When = when
-		if bl.outputs != nil {
-			bl.msgChan <- lm
+	if async {
+		bm := logMsgPool.Get().(*LogMsg)
+		*bm = *lm
+		if r.outputs != nil {
+			r.enqueue(bm)
 		} else {
-			logMsgPool.Put(lm)
+			logMsgPool.Put(bm)
 		}
 	} else {
-		bl.writeToLoggers(when, msg, logLevel)
+		r.writeToLoggers(lm)
 	}
-	return nil
 }
 
 func (bl *Loguru) SetLevel(l int) {
-	bl.level = l
+	atomic.StoreInt32(&bl.level, int32(l))
+	atomic.StoreInt32(&bl.levelSet, 1)
 }
 
 func (bl *Loguru) GetLevel() int {
-	return bl.level
+	if bl.parent != nil && atomic.LoadInt32(&bl.levelSet) == 0 {
+		return bl.parent.GetLevel()
+	}
+	return int(atomic.LoadInt32(&bl.level))
 }
 
+// SetLogFuncCallDepth reports the caller d frames above the logger's
+// internals instead of the first frame outside loguru and its helpers.
 func (bl *Loguru) SetLogFuncCallDepth(d int) {
-	bl.loggerFuncCallDepth = d
+	r := bl.root()
+	r.loggerFuncCallDepth = d
+	r.funcCallDepthSet = true
 }
 
 func (bl *Loguru) GetLogFuncCallDepth() int {
-	return bl.loggerFuncCallDepth
+	return bl.root().loggerFuncCallDepth
 }
 
 func (bl *Loguru) EnableFuncCallDepth(b bool) {
-	bl.enableFuncCallDepth = b
+	bl.root().enableFuncCallDepth = b
 }
 
 func (bl *Loguru) SetPrefix(s string) {
-	bl.prefix = s
+	bl.root().prefix = s
 }
 
 func (bl *Loguru) startLogger() {
@@ -291,8 +439,7 @@ func (bl *Loguru) startLogger() {
 	for {
 		select {
 		case bm := <-bl.msgChan:
-			bl.writeToLoggers(bm.When, bm.Msg, bm.Level)
-			logMsgPool.Put(bm)
+			bl.writeQueued(bm)
 		case sg := <-bl.signalChan:
 			bl.flush()
 			if sg == "close" {
@@ -311,98 +458,113 @@ func (bl *Loguru) startLogger() {
 }
 
 func (bl *Loguru) Emergency(format string, v ...interface{}) {
-	if LevelEmergency > bl.level {
+	if !bl.enabled(LevelEmergency) {
 		return
 	}
 	_ = bl.writeMsg(LevelEmergency, format, v...)
 }
 
 func (bl *Loguru) Alert(format string, v ...interface{}) {
-	if LevelAlert > bl.level {
+	if !bl.enabled(LevelAlert) {
 		return
 	}
 	_ = bl.writeMsg(LevelAlert, format, v...)
 }
 
 func (bl *Loguru) Critical(format string, v ...interface{}) {
-	if LevelCritical > bl.level {
+	if !bl.enabled(LevelCritical) {
 		return
 	}
 	_ = bl.writeMsg(LevelCritical, format, v...)
 }
 
 func (bl *Loguru) Error(format string, v ...interface{}) {
-	if LevelError > bl.level {
+	if !bl.enabled(LevelError) {
 		return
 	}
 	_ = bl.writeMsg(LevelError, format, v...)
 }
 
 func (bl *Loguru) Warning(format string, v ...interface{}) {
-	if LevelWarn > bl.level {
+	if !bl.enabled(LevelWarn) {
 		return
 	}
 	_ = bl.writeMsg(LevelWarn, format, v...)
 }
 
 func (bl *Loguru) Notice(format string, v ...interface{}) {
-	if LevelNotice > bl.level {
+	if !bl.enabled(LevelNotice) {
 		return
 	}
 	_ = bl.writeMsg(LevelNotice, format, v...)
 }
 
 func (bl *Loguru) Informational(format string, v ...interface{}) {
-	if LevelInfo > bl.level {
+	if !bl.enabled(LevelInfo) {
 		return
 	}
 	_ = bl.writeMsg(LevelInfo, format, v...)
 }
 
 func (bl *Loguru) Debug(format string, v ...interface{}) {
-	if LevelDebug > bl.level {
+	if !bl.enabled(LevelDebug) {
 		return
 	}
 	_ = bl.writeMsg(LevelDebug, format, v...)
 }
 
 func (bl *Loguru) Warn(format string, v ...interface{}) {
-	if LevelWarn > bl.level {
+	if !bl.enabled(LevelWarn) {
 		return
 	}
 	_ = bl.writeMsg(LevelWarn, format, v...)
 }
 
 func (bl *Loguru) Info(format string, v ...interface{}) {
-	if LevelInfo > bl.level {
+	if !bl.enabled(LevelInfo) {
 		return
 	}
 	_ = bl.writeMsg(LevelInfo, format, v...)
 }
 
 func (bl *Loguru) Success(format string, v ...interface{}) {
-	if LevelSuccess > bl.level {
+	if !bl.enabled(LevelSuccess) {
 		return
 	}
 	_ = bl.writeMsg(LevelSuccess, format, v...)
 }
 
 func (bl *Loguru) Input(format string, v ...interface{}) {
-	if LevelDebug > bl.level {
+	if !bl.enabled(LevelDebug) {
 		return
 	}
 	_ = bl.writeMsg(LevelInput, format, v...)
 }
 
 func (bl *Loguru) Trace(format string, v ...interface{}) {
-	if LevelDebug > bl.level {
+	if !bl.enabled(LevelDebug) {
 		return
 	}
 	_ = bl.writeMsg(LevelDebug, format, v...)
 }
 
+func (bl *Loguru) Log(level int, format string, v ...interface{}) {
+	if !bl.enabled(level) {
+		return
+	}
+	_ = bl.writeMsg(level, format, v...)
+}
+
+func (bl *Loguru) enabled(level int) bool {
+	return levelEnabled(level, bl.GetLevel())
+}
+
 func (bl *Loguru) Flush() {
-	if bl.asynchronous {
+	bl = bl.root()
+	bl.lock.Lock()
+	async := bl.asynchronous && !bl.closed
+	bl.lock.Unlock()
+	if async {
 		bl.signalChan <- "flush"
 		bl.wg.Wait()
 		bl.wg.Add(1)
@@ -411,11 +573,25 @@ func (bl *Loguru) Flush() {
 	bl.flush()
 }
 
+// Close flushes and destroys the outputs. Messages logged afterwards are
+// written synchronously, so the queue of an Async logger is never closed
+// under goroutines that still log.
 func (bl *Loguru) Close() {
-	if bl.asynchronous {
+	bl = bl.root()
+	bl.lock.Lock()
+	if bl.closed {
+		bl.lock.Unlock()
+		return
+	}
+	bl.closed = true
+	async := bl.asynchronous
+	bl.lock.Unlock()
+	if async {
 		bl.signalChan <- "close"
 		bl.wg.Wait()
-		close(bl.msgChan)
+		bl.lock.Lock()
+		bl.asynchronous = false
+		bl.lock.Unlock()
 	} else {
 		bl.flush()
 		for _, l := range bl.outputs {
@@ -427,6 +603,7 @@ func (bl *Loguru) Close() {
 }
 
 func (bl *Loguru) Reset() {
+	bl = bl.root()
 	bl.Flush()
 	for _, l := range bl.outputs {
 		l.Destroy()
@@ -436,14 +613,18 @@ func (bl *Loguru) Reset() {
 
 func (bl *Loguru) flush() {
 	if bl.asynchronous {
-		for {
-			if len(bl.msgChan) > 0 {
-				bm := <-bl.msgChan
-				bl.writeToLoggers(bm.When, bm.Msg, bm.Level)
-				logMsgPool.Put(bm)
-				continue
+		for drained := false; !drained; {
+			select {
+			case bm := <-bl.msgChan:
+				bl.writeQueued(bm)
+			default:
+				drained = true
 			}
-			break
+		}
+	}
+	if d := bl.dedup; d != nil {
+		if summary := d.flush(); summary != nil {
+			bl.writeOutputs(summary)
 		}
 	}
 	for _, l := range bl.outputs {
@@ -454,7 +635,7 @@ func (bl *Loguru) flush() {
 var logger = NewLogger(Console)
 
 func GetLgLogger() *Loguru {
-	return logger
+	return std()
 }
 
 var loggerMap = struct {
@@ -480,101 +661,121 @@ func GetLogger(prefixes ...string) *log.Logger {
 	defer loggerMap.Unlock()
 	l, ok = loggerMap.logs[prefix]
 	if !ok {
-		l = log.New(logger, prefix, 0)
+		l = log.New(std(), prefix, 0)
 		loggerMap.logs[prefix] = l
 	}
 	return l
 }
 
 func Reset() {
-	logger.Reset()
+	std().Reset()
+}
+
+func With(keyvals ...interface{}) *Loguru {
+	return std().With(keyvals...)
 }
 
 func Async(msgLen ...int64) *Loguru {
-	return logger.Async(msgLen...)
+	return std().Async(msgLen...)
 }
 
 func SetLevel(l int) {
-	logger.SetLevel(l)
+	std().SetLevel(l)
+}
+
+func HandleLevelSignals() (stop func()) {
+	return std().HandleLevelSignals()
 }
 
 func SetPrefix(s string) {
-	logger.SetPrefix(s)
+	std().SetPrefix(s)
 }
 
 func EnableFuncCallDepth(b bool) {
-	logger.enableFuncCallDepth = b
+	std().enableFuncCallDepth = b
 }
 
 func SetLogFuncCall(b bool) {
-	logger.EnableFuncCallDepth(b)
-	logger.SetLogFuncCallDepth(4)
+	std().EnableFuncCallDepth(b)
+	std().SetLogFuncCallDepth(4)
 }
 
 func SetLogFuncCallDepth(d int) {
-	logger.loggerFuncCallDepth = d
+	std().SetLogFuncCallDepth(d)
 }
 
 func SetLogger(adapter string, config ...string) error {
-	return logger.SetLogger(adapter, config...)
+	return std().SetLogger(adapter, config...)
 }
 
 func Emergency(f interface{}, v ...interface{}) {
-	logger.Emergency(formatLog(f, v...))
+	std().Emergency(logTemplate(f, v...), v...)
 }
 
 func Alert(f interface{}, v ...interface{}) {
-	logger.Alert(formatLog(f, v...))
+	std().Alert(logTemplate(f, v...), v...)
 }
 
 func Critical(f interface{}, v ...interface{}) {
-	logger.Critical(formatLog(f, v...))
+	std().Critical(logTemplate(f, v...), v...)
 }
 
 func Error(f interface{}, v ...interface{}) {
-	logger.Error(formatLog(f, v...))
+	std().Error(logTemplate(f, v...), v...)
 }
 
 func Warning(f interface{}, v ...interface{}) {
-	logger.Warn(formatLog(f, v...))
+	std().Warn(logTemplate(f, v...), v...)
 }
 
 func Warn(f interface{}, v ...interface{}) {
-	logger.Warn(formatLog(f, v...))
+	std().Warn(logTemplate(f, v...), v...)
 }
 
 func Notice(f interface{}, v ...interface{}) {
-	logger.Notice(formatLog(f, v...))
+	std().Notice(logTemplate(f, v...), v...)
 }
 
 func Informational(f interface{}, v ...interface{}) {
-	logger.Info(formatLog(f, v...))
+	std().Info(logTemplate(f, v...), v...)
 }
 
 func Info(f interface{}, v ...interface{}) {
-	logger.Info(formatLog(f, v...))
+	std().Info(logTemplate(f, v...), v...)
 }
 
 func Debug(f interface{}, v ...interface{}) {
-	logger.Debug(formatLog(f, v...))
+	std().Debug(logTemplate(f, v...), v...)
+}
+
+func Log(level int, f interface{}, v ...interface{}) {
+	std().Log(level, logTemplate(f, v...), v...)
 }
 
 func Success(f interface{}, v ...interface{}) {
-	logger.Success(formatLog(f, v...))
+	std().Success(logTemplate(f, v...), v...)
 }
 
 func Trace(f interface{}, v ...interface{}) {
-	logger.Trace(formatLog(f, v...))
+	std().Trace(logTemplate(f, v...), v...)
 }
 
+// Input prompts and returns the first word of the answer; InputLine reads
+// the whole line. Like fmt.Scanln it takes nothing from os.Stdin past the
+// end of the line.
 func Input(f interface{}, v ...interface{}) string {
-	var r string
-	logger.Input(formatLog(f, v...))
-	fmt.Scanln(&r)
-	return r
+	std().Input(logTemplate(f, v...), v...)
+	line, _ := readLine()
+	if words := strings.Fields(line); len(words) > 0 {
+		return words[0]
+	}
+	return ""
 }
 
-func formatLog(f interface{}, v ...interface{}) string {
+// logTemplate turns the arguments of the package-level functions into a
+// format string for v, which is only formatted once the level is known to
+// be enabled.
+func logTemplate(f interface{}, v ...interface{}) string {
 	var msg string
 	switch f.(type) {
 	case string:
@@ -591,26 +792,19 @@ func formatLog(f interface{}, v ...interface{}) string {
 		if len(v) == 0 {
 			return msg
 		}
-		msg += strings.Repeat(" %v", len(v))
+		msg = strings.Replace(msg, "%", "%%", -1) + strings.Repeat(" %v", len(v))
 	}
-	return fmt.Sprintf(msg, v...)
+	return msg
 }
 
 func DelLogger(name string) error {
-	err := logger.DelLogger(name)
+	err := std().DelLogger(name)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func SetColor(level int, color string) {
-	colors = append(colors[:level], colors[level+1:]...)
-	after := append([]brush{}, colors[level:]...)
-	colors = append(colors[0:level], newBrush(color))
-	colors = append(colors, after...)
-}
-
 func ResetEmergencyColor(color string) {
 	SetColor(LevelEmergency, color)
 }
@@ -656,7 +850,7 @@ func ResetFileColor(color string) {
 }
 
 func ResetSpace(space int) {
-	logger.space = space
+	std().space = space
 }
 
 func Enable(mode int) error {
@@ -683,14 +877,14 @@ func Enable(mode int) error {
 		if err != nil {
 			return err
 		}
-		return logger.setLogger(AdapterFile, string(configBytes))
+		return std().setLogger(AdapterFile, string(configBytes))
 	case OnlineLog:
 		executePath, _ := os.Getwd()
 		configBytes, err := ioutil.ReadFile(executePath + "/logs/online.json")
 		if err != nil {
 			return err
 		}
-		return logger.setLogger(AdapterOnline, string(configBytes))
+		return std().setLogger(AdapterOnline, string(configBytes))
 	default:
 		return errors.New("unknown log type")
 	}
@@ -700,11 +894,15 @@ func Disable(mode int) error {
 	var err error
 	switch mode {
 	case FileLog:
-		err = logger.DelLogger(AdapterFile)
+		err = std().DelLogger(AdapterFile)
 	case OnlineLog:
-		err = logger.DelLogger(AdapterOnline)
+		err = std().DelLogger(AdapterOnline)
 	default:
 		err = errors.New("unknown log type")
 	}
 	return err
 }
+
+func Attach(name string, lg Logger) error {
+	return std().Attach(name, lg)
+}
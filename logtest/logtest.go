@@ -0,0 +1,194 @@
+// Package logtest captures what a loguru logger writes so tests can assert
+// on it instead of reading a terminal.
+package logtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Esbiya/loguru"
+)
+
+// AnyLevel matches messages of every level in the assertions.
+const AnyLevel = -1
+
+// Recorder is an adapter that keeps a copy of every message written to it.
+type Recorder struct {
+	mu      sync.Mutex
+	msgs    []loguru.LogMsg
+	forward func(args ...interface{})
+}
+
+func (r *Recorder) Init(config string) error { return nil }
+
+func (r *Recorder) WriteMsg(lm *loguru.LogMsg) error {
+	m := *lm
+	m.Fields = append([]loguru.Field(nil), lm.Fields...)
+	r.mu.Lock()
+	r.msgs = append(r.msgs, m)
+	forward := r.forward
+	r.mu.Unlock()
+	if forward != nil {
+		forward(m.NormalFormat())
+	}
+	return nil
+}
+
+func (r *Recorder) Destroy() {}
+
+func (r *Recorder) Flush() {}
+
+func (r *Recorder) SetFormatter(f loguru.LogFormatter) {}
+
+// Entries returns the messages recorded so far, oldest first.
+func (r *Recorder) Entries() []loguru.LogMsg {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]loguru.LogMsg(nil), r.msgs...)
+}
+
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.msgs = nil
+	r.mu.Unlock()
+}
+
+// Logger is a loguru logger whose only output is a Recorder.
+type Logger struct {
+	*loguru.Loguru
+	rec *Recorder
+	t   testing.TB
+}
+
+type Option func(l *Logger)
+
+// ForwardToT also writes every message with t.Log, so it shows up under the
+// test that logged it.
+func ForwardToT() Option {
+	return func(l *Logger) {
+		l.rec.forward = l.t.Log
+	}
+}
+
+func New(t testing.TB, opts ...Option) *Logger {
+	l := &Logger{Loguru: loguru.NewLogger(0), rec: &Recorder{}, t: t}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if err := l.Attach("logtest", l.rec); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		l.rec.mu.Lock()
+		l.rec.forward = nil
+		l.rec.mu.Unlock()
+	})
+	return l
+}
+
+func (l *Logger) Entries() []loguru.LogMsg {
+	return l.rec.Entries()
+}
+
+func (l *Logger) Reset() {
+	l.rec.Reset()
+}
+
+// Match reports whether lm is at level, contains substr and carries the
+// key/value pairs in keyvals. Values are compared with reflect.DeepEqual or,
+// failing that, by their fmt.Sprint text.
+func Match(lm loguru.LogMsg, level int, substr string, keyvals ...interface{}) bool {
+	if level != AnyLevel && lm.Level != level {
+		return false
+	}
+	if !strings.Contains(lm.Msg, substr) {
+		return false
+	}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if !hasField(lm.Fields, fmt.Sprint(keyvals[i]), keyvals[i+1]) {
+			return false
+		}
+	}
+	return len(keyvals)%2 == 0
+}
+
+func hasField(fields []loguru.Field, key string, value interface{}) bool {
+	for _, f := range fields {
+		if f.Key != key {
+			continue
+		}
+		if reflect.DeepEqual(f.Value, value) || fmt.Sprint(f.Value) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Logger) find(level int, substr string, keyvals []interface{}) int {
+	for i, lm := range l.Entries() {
+		if Match(lm, level, substr, keyvals...) {
+			return i
+		}
+	}
+	return -1
+}
+
+// AssertLogged fails the test unless a message matching level, substr and
+// keyvals was logged.
+func (l *Logger) AssertLogged(level int, substr string, keyvals ...interface{}) bool {
+	l.t.Helper()
+	if l.find(level, substr, keyvals) < 0 {
+		l.t.Errorf("logtest: no %s; logged:\n%s", describe(level, substr, keyvals), l.dump())
+		return false
+	}
+	return true
+}
+
+func (l *Logger) AssertNotLogged(level int, substr string, keyvals ...interface{}) bool {
+	l.t.Helper()
+	if i := l.find(level, substr, keyvals); i >= 0 {
+		l.t.Errorf("logtest: unexpected %s: %q", describe(level, substr, keyvals), l.Entries()[i].Msg)
+		return false
+	}
+	return true
+}
+
+// AssertOrder fails the test unless messages containing each of substrs
+// were logged in that order, possibly with others in between.
+func (l *Logger) AssertOrder(substrs ...string) bool {
+	l.t.Helper()
+	next := 0
+	for _, lm := range l.Entries() {
+		if next < len(substrs) && strings.Contains(lm.Msg, substrs[next]) {
+			next++
+		}
+	}
+	if next < len(substrs) {
+		l.t.Errorf("logtest: %q not logged after %q; logged:\n%s", substrs[next], substrs[:next], l.dump())
+		return false
+	}
+	return true
+}
+
+func (l *Logger) dump() string {
+	var b strings.Builder
+	for _, lm := range l.Entries() {
+		b.WriteString("\t" + lm.NormalFormat() + "\n")
+	}
+	return b.String()
+}
+
+func describe(level int, substr string, keyvals []interface{}) string {
+	s := "message"
+	if level != AnyLevel {
+		s = loguru.LevelName(level) + " message"
+	}
+	s += fmt.Sprintf(" containing %q", substr)
+	if len(keyvals) > 0 {
+		s += fmt.Sprintf(" with %v", keyvals)
+	}
+	return s
+}
@@ -0,0 +1,50 @@
+package logtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Esbiya/loguru"
+)
+
+func TestLogger(t *testing.T) {
+	l := New(t, ForwardToT())
+	l.With("user", 42).Info("user logged in")
+	l.Warn("disk at %d%%", 91)
+	l.Debug("done")
+
+	l.AssertLogged(loguru.LevelInfo, "logged in", "user", 42)
+	l.AssertLogged(AnyLevel, "disk at 91%")
+	l.AssertNotLogged(loguru.LevelError, "")
+	l.AssertOrder("logged in", "disk", "done")
+
+	entries := l.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("recorded %d messages, want 3", len(entries))
+	}
+	if !strings.HasSuffix(entries[0].FilePath, "logtest_test.go") {
+		t.Fatalf("unexpected caller %q", entries[0].FilePath)
+	}
+
+	ft := &fakeT{TB: t}
+	l.t = ft
+	l.AssertLogged(loguru.LevelInfo, "logged in", "user", 43)
+	l.AssertOrder("done", "disk")
+	if ft.failures != 2 {
+		t.Fatalf("got %d failures, want 2", ft.failures)
+	}
+
+	l.Reset()
+	if len(l.Entries()) != 0 {
+		t.Fatal("Reset kept messages")
+	}
+}
+
+type fakeT struct {
+	testing.TB
+	failures int
+}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.failures++
+}
@@ -1,30 +1,1347 @@
 package loguru
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
 )
 
 func TestLog(t *testing.T) {
-	// 终端输出
-	ResetTimeColor(FUCHSIA)
-	ResetDebugColor(GREEN)
+	bl, mw := newMemoryLogger()
+	saved := std()
+	logger = bl
+	defer func() { logger = saved }()
+	SetInput(strings.NewReader("张三 李四\n王五\n"))
+	defer SetInput(os.Stdin)
+
 	Debug("111")
+	if lm := mw.last(); lm.Level != LevelDebug || lm.Msg != "111" {
+		t.Fatalf("unexpected message %d %q", lm.Level, lm.Msg)
+	}
+	if x := Input("请输入: "); x != "张三" {
+		t.Fatalf("Input = %q", x)
+	}
+	if lm := mw.last(); lm.Level != LevelInput || lm.Msg != "请输入: " {
+		t.Fatalf("unexpected prompt %d %q", lm.Level, lm.Msg)
+	}
+	if y := Input("请输入2: "); y != "王五" {
+		t.Fatalf("Input = %q", y)
+	}
+}
+
+type memoryWriter struct {
+	sync.Mutex
+	msgs []*LogMsg
+}
+
+func (m *memoryWriter) Init(config string) error { return nil }
+
+func (m *memoryWriter) WriteMsg(lm *LogMsg) error {
+	m.Lock()
+	m.msgs = append(m.msgs, lm)
+	m.Unlock()
+	return nil
+}
+
+func (m *memoryWriter) Destroy() {}
+
+func (m *memoryWriter) Flush() {}
+
+func (m *memoryWriter) SetFormatter(f LogFormatter) {}
+
+func (m *memoryWriter) last() *LogMsg {
+	m.Lock()
+	defer m.Unlock()
+	if len(m.msgs) == 0 {
+		return nil
+	}
+	return m.msgs[len(m.msgs)-1]
+}
+
+func newMemoryLogger() (*Loguru, *memoryWriter) {
+	bl := NewLogger(0)
+	mw := &memoryWriter{}
+	bl.init = true
+	bl.outputs = []*nameLogger{{name: "memory", Logger: mw}}
+	return bl, mw
+}
+
+func TestWith(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	child := bl.With("user", 42, "shard", "eu")
+	grandchild := child.With("request", "r-1")
+
+	grandchild.Info("hello")
+	fields := mw.last().Fields
+	if len(fields) != 3 || fields[0].Key != "user" || fields[2].Key != "request" {
+		t.Fatalf("unexpected fields: %v", fields)
+	}
+
+	child.Info("hello")
+	if n := len(mw.last().Fields); n != 2 {
+		t.Fatalf("grandchild fields leaked into child: %d", n)
+	}
+
+	bl.Info("hello")
+	if n := len(mw.last().Fields); n != 0 {
+		t.Fatalf("child fields leaked into parent: %d", n)
+	}
+
+	bl.SetLevel(LevelError)
+	grandchild.Info("hidden")
+	if len(mw.msgs) != 3 {
+		t.Fatalf("child should follow parent level, got %d messages", len(mw.msgs))
+	}
+
+	var perr *os.PathError
+	var sb *strings.Builder
+	if out := formatFields([]Field{F("err", perr), F("buf", sb)}, nil); out != " err=<nil> buf=<nil>" {
+		t.Fatalf("unexpected typed nil fields %q", out)
+	}
+	lm := &LogMsg{Level: LevelInfo, Msg: "nil", When: time.Now(), Fields: []Field{F("err", perr)}, Err: perr}
+	out := (&JSONLogFormatter{}).Format(lm)
+	var entry struct {
+		Error  string
+		Fields map[string]string
+	}
+	if err := json.Unmarshal([]byte(out), &entry); err != nil || entry.Error != "<nil>" || entry.Fields["err"] != "<nil>" {
+		t.Fatalf("unexpected typed nil json %s %v", out, err)
+	}
+}
+
+type traceKey struct{}
+
+func TestContext(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	RegisterContextExtractor("trace", ContextValue(traceKey{}, "trace_id"))
+	defer UnregisterContextExtractor("trace")
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "abc")
+	ctx = ContextWithFields(ctx, "request_id", "r-1")
+	ctx = NewContext(ctx, bl)
+	if FromContext(ctx) != bl {
+		t.Fatal("FromContext did not return the stored logger")
+	}
+
+	FromContext(ctx).InfoCtx(ctx, "served %s", "/")
+	lm := mw.last()
+	if !strings.HasSuffix(lm.Msg, "served /") {
+		t.Fatalf("unexpected message %q", lm.Msg)
+	}
+	got := map[string]interface{}{}
+	for _, f := range lm.Fields {
+		got[f.Key] = f.Value
+	}
+	if got["request_id"] != "r-1" || got["trace_id"] != "abc" {
+		t.Fatalf("context fields missing: %v", lm.Fields)
+	}
+}
+
+func TestNamed(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	if err := bl.SetLevels("error,db=warn,db.sql=debug"); err != nil {
+		t.Fatal(err)
+	}
+	if bl.Named("db").Named("sql") != bl.Named("db.sql") {
+		t.Fatal("named loggers should be shared")
+	}
+
+	bl.Named("db.pool").Info("hidden")
+	bl.Named("db.pool").Warn("pool exhausted")
+	bl.Named("db.sql").Debug("select 1")
+	bl.Named("http").Warn("hidden")
+	if len(mw.msgs) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(mw.msgs))
+	}
+	if mw.msgs[0].Name != "db.pool" || mw.last().Name != "db.sql" {
+		t.Fatalf("unexpected names %q %q", mw.msgs[0].Name, mw.last().Name)
+	}
+	if err := bl.SetLevels("db=loud"); err == nil {
+		t.Fatal("expected an error for an unknown level")
+	}
+
+	req := bl.With("request", 7)
+	if err := req.SetLevels("db=error"); err != nil {
+		t.Fatal(err)
+	}
+	if l := bl.Named("db").GetLevel(); l != LevelError {
+		t.Fatalf("SetLevels on a With logger did not reach db: %d", l)
+	}
+	srv := httptest.NewServer(LevelHandler(req))
+	defer srv.Close()
+	resp, err := http.Post(srv.URL+"?level=notice&logger=db", "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if l := bl.Named("db").GetLevel(); l != LevelNotice {
+		t.Fatalf("LevelHandler on a With logger did not reach db: %d", l)
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	srv := httptest.NewServer(LevelHandler(bl))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader(`{"level": "warn"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || bl.GetLevel() != LevelWarn {
+		t.Fatalf("level not changed: %d %d", resp.StatusCode, bl.GetLevel())
+	}
+	if lm := mw.last(); lm == nil || !strings.Contains(lm.Msg, "from debug to warning") {
+		t.Fatalf("level change was not logged: %v", lm)
+	}
+
+	resp, err = http.Post(srv.URL+"?level=loud", "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var state struct {
+		Level string `json:"level"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil || state.Level != "warning" {
+		t.Fatalf("unexpected state %v %v", state, err)
+	}
+
+	resp, err = http.Get(srv.URL + "?logger=db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || len(bl.named) != 0 {
+		t.Fatalf("GET of unknown logger: %d %v", resp.StatusCode, bl.named)
+	}
+
+	if _, err := bl.AddFunc(func(lm *LogMsg) error { return nil }, SinkLevel(LevelDebug)); err != nil {
+		t.Fatal(err)
+	}
+	req, _ = http.NewRequest(http.MethodPut, srv.URL+"?level=error&output=func", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || bl.AdapterLevels()["func"] != LevelError || bl.GetLevel() != LevelWarn {
+		t.Fatalf("output level not changed: %d %v %d", resp.StatusCode, bl.AdapterLevels(), bl.GetLevel())
+	}
+	if lm := mw.last(); lm == nil || !strings.Contains(lm.Msg, "output func level changed from debug to error") {
+		t.Fatalf("output level change was not logged: %v", lm)
+	}
+}
+
+func TestLevelChangesWhileLogging(t *testing.T) {
+	bl := NewLogger(0)
+	bl.EnableFuncCallDepth(false)
+	if _, err := bl.AddFunc(func(lm *LogMsg) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(LevelHandler(bl))
+	defer srv.Close()
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				bl.Info("tick")
+			}
+		}
+	}()
+	for i, level := range []string{"warn", "debug", "error", "info"} {
+		resp, err := http.Post(srv.URL+"?level="+level, "text/plain", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if err := bl.SetAdapterLevel("func", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	<-done
+	if bl.GetLevel() != LevelInfo || bl.AdapterLevels()["func"] != 3 {
+		t.Fatalf("unexpected levels %d %v", bl.GetLevel(), bl.AdapterLevels())
+	}
+}
+
+func TestSampling(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(false)
+	bl.SetSampling(&Sampling{First: 2, Thereafter: 5, Interval: time.Hour})
+	for i := 0; i < 12; i++ {
+		bl.Debug("tick %d", i)
+	}
+	bl.Debug("other")
+	// ticks 0 and 1 pass, then every 5th after the first two: 6 and 11.
+	if len(mw.msgs) != 5 {
+		t.Fatalf("expected 5 messages, got %d", len(mw.msgs))
+	}
+	if msg := mw.msgs[2].Msg; msg != "tick 6 (4 similar messages suppressed)" {
+		t.Fatalf("unexpected message %q", msg)
+	}
+	if n := bl.SampledOut(); n != 8 {
+		t.Fatalf("expected 8 dropped, got %d", n)
+	}
+}
+
+func TestAdapterSampling(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(false)
+	remote := &memoryWriter{}
+	if err := bl.Attach("remote", remote); err != nil {
+		t.Fatal(err)
+	}
+	if err := bl.SetAdapterSampling("remote", &Sampling{First: 1, Thereafter: 3, Interval: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bl.SetAdapterSampling("missing", &Sampling{First: 1}); err == nil {
+		t.Fatal("sampling set on an unknown adapter")
+	}
+	for i := 0; i < 7; i++ {
+		bl.Info("tick %d", i)
+	}
+	// remote keeps tick 0, then every 3rd after it: 3 and 6.
+	if len(mw.msgs) != 7 || len(remote.msgs) != 3 {
+		t.Fatalf("expected 7 and 3 messages, got %d and %d", len(mw.msgs), len(remote.msgs))
+	}
+	if n := bl.SampledOut(); n != 4 {
+		t.Fatalf("expected 4 dropped, got %d", n)
+	}
+
+	if err := bl.SetAdapterSampling("remote", nil); err != nil {
+		t.Fatal(err)
+	}
+	bl.Info("tick 7")
+	if len(remote.msgs) != 4 {
+		t.Fatalf("sampling not turned off: %d messages", len(remote.msgs))
+	}
+}
+
+func TestDedup(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		bl, mw := newMemoryLogger()
+		bl.EnableFuncCallDepth(false)
+		bl.SetDedup(time.Minute)
+		if async {
+			bl.Async()
+		}
+		for i := 0; i < 5; i++ {
+			bl.Error("db down")
+		}
+		bl.Info("db up")
+		bl.Info("db up")
+		bl.Flush()
+
+		var got []string
+		for _, lm := range mw.msgs {
+			got = append(got, lm.Msg)
+		}
+		want := "db down|last message repeated 4 times|db up|last message repeated 1 times"
+		if strings.Join(got, "|") != want {
+			t.Fatalf("async=%v: unexpected messages %q", async, got)
+		}
+	}
+
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(false)
+	bl.SetDedup(time.Minute)
+	for _, user := range []int{1, 2, 2} {
+		bl.With("user", user).Error("login failed")
+	}
+	bl.Named("db").Error("login failed")
+	bl.Flush()
+	var got []string
+	for _, lm := range mw.msgs {
+		got = append(got, lm.Msg+" "+lm.Name+formatFields(lm.Fields, nil))
+	}
+	want := "login failed  user=1|login failed  user=2|last message repeated 1 times  user=2|login failed db"
+	if strings.Join(got, "|") != want {
+		t.Fatalf("unexpected messages %q", got)
+	}
+}
+
+type blockingWriter struct {
+	memoryWriter
+	release chan struct{}
+}
+
+func (b *blockingWriter) WriteMsg(lm *LogMsg) error {
+	<-b.release
+	// Async messages go back to the pool once written, so keep a copy.
+	m := *lm
+	return b.memoryWriter.WriteMsg(&m)
+}
+
+func TestAsyncOverflow(t *testing.T) {
+	bl := NewLogger(0)
+	bw := &blockingWriter{release: make(chan struct{})}
+	bl.init = true
+	bl.outputs = []*nameLogger{{name: "blocking", Logger: bw}}
+	bl.EnableFuncCallDepth(false)
+	bl.SetOverflowPolicy(OverflowDropNewest)
+	bl.SetNeverDropLevel(LevelError)
+	bl.Async(2)
+
+	// The writer goroutine may take one message off the queue and block.
+	for i := 0; i < 10; i++ {
+		bl.Debug("noise %d", i)
+	}
+	done := make(chan struct{})
+	go func() {
+		bl.Error("important")
+		close(done)
+	}()
+	close(bw.release)
+	<-done
+	bl.Flush()
+
+	stats := bl.AsyncStats()
+	if stats.Dropped == 0 || stats.Enqueued+stats.Dropped != 11 || stats.Written != stats.Enqueued {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if stats.HighWaterMark != 2 {
+		t.Fatalf("unexpected high-water mark %d", stats.HighWaterMark)
+	}
+	if bw.last().Msg != "important" {
+		t.Fatalf("protected message was dropped, last is %q", bw.last().Msg)
+	}
+}
+
+func newBlockedAsyncLogger(policy OverflowPolicy, timeout time.Duration, queue int64) (*Loguru, *blockingWriter) {
+	bl := NewLogger(0)
+	bw := &blockingWriter{release: make(chan struct{})}
+	bl.init = true
+	bl.outputs = []*nameLogger{{name: "blocking", Logger: bw}}
+	bl.EnableFuncCallDepth(false)
+	bl.SetOverflowPolicy(policy, timeout)
+	bl.SetNeverDropLevel(LevelError)
+	bl.Async(queue)
+
+	// Wait until the writer goroutine holds the first message and blocks.
+	bl.Debug("first")
+	for len(bl.msgChan) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	return bl, bw
+}
+
+func writtenMsgs(bw *blockingWriter) []string {
+	bw.Lock()
+	defer bw.Unlock()
+	var msgs []string
+	for _, lm := range bw.msgs {
+		msgs = append(msgs, lm.Msg)
+	}
+	return msgs
+}
+
+func TestAsyncOverflowDropOldest(t *testing.T) {
+	bl, bw := newBlockedAsyncLogger(OverflowDropOldest, 0, 2)
+	for i := 0; i < 4; i++ {
+		bl.Debug("noise %d", i)
+	}
+	close(bw.release)
+	bl.Flush()
+	if got := strings.Join(writtenMsgs(bw), ","); got != "first,noise 2,noise 3" {
+		t.Fatalf("unexpected messages %s", got)
+	}
+	if stats := bl.AsyncStats(); stats.Dropped != 2 || stats.Written != 3 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	// With protected messages queued, a full queue waits for room instead of
+	// dropping around them or changing their order.
+	bl, bw = newBlockedAsyncLogger(OverflowDropOldest, 0, 3)
+	bl.Error("err 1")
+	bl.Error("err 2")
+	bl.Debug("debug 1")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		bl.Debug("debug 2")
+	}()
+	select {
+	case <-done:
+		t.Fatal("full queue with protected messages did not wait")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(bw.release)
+	<-done
+	bl.Flush()
+	if got := strings.Join(writtenMsgs(bw), ","); got != "first,err 1,err 2,debug 1,debug 2" {
+		t.Fatalf("unexpected messages %s", got)
+	}
+	if stats := bl.AsyncStats(); stats.Dropped != 0 || stats.Written != 5 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestAsyncOverflowBlockTimeout(t *testing.T) {
+	bl, bw := newBlockedAsyncLogger(OverflowBlockTimeout, 20*time.Millisecond, 1)
+	bl.Debug("queued")
+	start := time.Now()
+	bl.Debug("dropped")
+	if d := time.Since(start); d < 20*time.Millisecond {
+		t.Fatalf("gave up after %v", d)
+	}
+	close(bw.release)
+	bl.Debug("after")
+	bl.Flush()
+
+	if got := strings.Join(writtenMsgs(bw), ","); got != "first,queued,after" {
+		t.Fatalf("unexpected messages %s", got)
+	}
+	if stats := bl.AsyncStats(); stats.Dropped != 1 || stats.Written != 3 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCustomLevel(t *testing.T) {
+	audit, err := ParseLevel("AUDIT")
+	if err != nil {
+		audit = RegisterLevel("audit", LevelWarning, "cyan")
+	}
+	if l, err := ParseLevel("AUDIT"); err != nil || l != audit {
+		t.Fatalf("ParseLevel(AUDIT) = %d, %v", l, err)
+	}
+	if LevelName(audit) != "audit" {
+		t.Fatalf("unexpected name %q", LevelName(audit))
+	}
+
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(false)
+	bl.SetLevel(LevelWarning)
+	bl.Log(audit, "user %s logged in", "bob")
+	bl.SetLevel(LevelError)
+	bl.Log(audit, "hidden")
+	if len(mw.msgs) != 1 || mw.last().Level != audit {
+		t.Fatalf("unexpected messages %v", mw.msgs)
+	}
+	if out := mw.last().NormalFormat(); !strings.HasPrefix(out, "| AUDIT") {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestErrorChain(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(false)
+	bl.SetStackTraceLevel(LevelCritical)
+
+	cause := pkgerrors.New("connection refused")
+	err := fmt.Errorf("load users: %w", pkgerrors.Wrap(cause, "dial db"))
+	bl.ErrorErr(err, "request failed")
+	out := mw.last().NormalFormat()
+	for _, want := range []string{
+		`error="load users: dial db: connection refused"`,
+		"\n    caused by: dial db: connection refused",
+		"\n    caused by: connection refused",
+		"TestErrorChain",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("%q not found in %q", want, out)
+		}
+	}
+
+	bl.Critical("disk full")
+	if stack := mw.last().Stack; !strings.Contains(stack, "TestErrorChain") {
+		t.Fatalf("critical message has no stack: %q", stack)
+	}
+	bl.Error("no stack")
+	if stack := mw.last().Stack; stack != "" {
+		t.Fatalf("unexpected stack: %q", stack)
+	}
+
+	var buf bytes.Buffer
+	if _, err := bl.AddWriter(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bl.AddWriter(&buf, SinkColor(true)); err != nil {
+		t.Fatal(err)
+	}
+	var perr *os.PathError
+	bl.ErrorErr(perr, "typed nil")
+	if out := buf.String(); strings.Count(out, "typed nil") != 2 || !strings.Contains(out, "error=<nil>") {
+		t.Fatalf("typed nil error not written: %q", out)
+	}
+}
+
+func TestRecoverAndCatch(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(false)
+
+	func() {
+		defer bl.Recover()
+		panic("boom")
+	}()
+	lm := mw.last()
+	if lm.Level != LevelCritical || lm.Msg != "panic: boom" || !strings.Contains(lm.Stack, "TestRecoverAndCatch") {
+		t.Fatalf("unexpected panic message %q %q", lm.Msg, lm.Stack)
+	}
+
+	err := bl.Catch(func() error { return fmt.Errorf("bad input") })
+	if err == nil || mw.last().Err != err {
+		t.Fatalf("returned error was not logged: %v", err)
+	}
+
+	bl.SetRepanic(true)
+	defer func() {
+		if r := recover(); r != "again" {
+			t.Fatalf("expected re-panic, got %v", r)
+		}
+	}()
+	_ = bl.Catch(func() error { panic("again") })
+}
+
+func TestFatal(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.Async()
+	code := -1
+	osExit = func(c int) { code = c }
+	defer func() { osExit = os.Exit }()
+	hooked := false
+	RegisterExitHook(func() { hooked = true })
+
+	for i := 0; i < 100; i++ {
+		bl.Info("queued %d", i)
+	}
+	bl.Fatal("giving up")
+	if code != 1 || !hooked {
+		t.Fatalf("exit code %d, hook run %v", code, hooked)
+	}
+	if len(mw.msgs) != 101 || mw.last().Level != LevelFatal {
+		t.Fatalf("queued messages were lost: %d", len(mw.msgs))
+	}
+
+	// Console mode adds its adapter back on the next message; logging from
+	// other goroutines before the process exits must not panic.
+	late := &memoryWriter{}
+	if err := bl.Attach("late", late); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		bl.Info("after close")
+	}()
+	<-done
+	bl.Flush()
+	bl.Close()
+	if lm := late.last(); lm == nil || lm.Msg != "after close" {
+		t.Fatalf("message after Close not written: %v", lm)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(false)
+	var order []string
+	bl.AddHook(FieldsHook("env", "prod"))
+	bl.AddHook(HookFunc(func(lm *LogMsg) bool {
+		order = append(order, "root")
+		return !strings.Contains(lm.Msg, "secret")
+	}))
+	child := bl.With("user", 1)
+	child.AddHook(HookFunc(func(lm *LogMsg) bool {
+		order = append(order, "child")
+		lm.Msg = strings.ToUpper(lm.Msg)
+		return true
+	}))
+
+	child.Info("hello")
+	bl.Info("secret stuff")
+	if len(mw.msgs) != 1 {
+		t.Fatalf("vetoed message was written: %d", len(mw.msgs))
+	}
+	lm := mw.last()
+	if lm.Msg != "HELLO" || len(lm.Fields) != 2 || lm.Fields[1].Key != "env" {
+		t.Fatalf("unexpected message %q %v", lm.Msg, lm.Fields)
+	}
+	if strings.Join(order, ",") != "root,child,root" {
+		t.Fatalf("unexpected hook order %v", order)
+	}
+	if n := len(child.Fields()); n != 1 {
+		t.Fatalf("hook fields leaked into the logger: %d", n)
+	}
+}
+
+func TestRedactor(t *testing.T) {
+	r, err := NewRedactor(RedactConfig{
+		Detectors: []string{"bearer", "email", "creditcard", "ipv4"},
+		Patterns:  []string{`sk_live_[a-z0-9]+`},
+		DenyKeys:  []string{"Password"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(false)
+	bl.SetRedactor(r)
+	child := bl.With("password", "hunter2", "card", "4111 1111 1111 1111")
+
+	child.Info("Authorization: Bearer abc.def from 10.0.0.1 by bob@example.com key sk_live_x1 order 1234567890123")
+	lm := mw.last()
+	want := "Authorization: Bearer [REDACTED] from [REDACTED] by [REDACTED] key [REDACTED] order 1234567890123"
+	if lm.Msg != want {
+		t.Fatalf("unexpected message %q", lm.Msg)
+	}
+	if lm.Fields[0].Value != redactedText || lm.Fields[1].Value != redactedText {
+		t.Fatalf("fields not masked: %v", lm.Fields)
+	}
+	if child.Fields()[0].Value != "hunter2" {
+		t.Fatal("redaction changed the logger's own fields")
+	}
+	if r.Count() != 6 {
+		t.Fatalf("unexpected count %d", r.Count())
+	}
+
+	partial, _ := NewRedactor(RedactConfig{Detectors: []string{"creditcard"}, Mask: MaskPartial})
+	if s, n := partial.RedactString("card 4111111111111111"); s != "card ************1111" || n != 1 {
+		t.Fatalf("unexpected partial mask %q %d", s, n)
+	}
+
+	child = bl.With("err", fmt.Errorf("no user bob@example.com"), "header", []byte("Bearer abc"), "card", 4111111111111111, "id", 7)
+	m := &LogMsg{Level: LevelError, Msg: "failed", Fields: child.Fields(), Stack: "main.login(bob@example.com)"}
+	if n := r.Redact(m); n != 4 {
+		t.Fatalf("unexpected count %d", n)
+	}
+	if m.Fields[0].Value != "no user [REDACTED]" || m.Fields[1].Value != "Bearer [REDACTED]" || m.Fields[2].Value != redactedText || m.Fields[3].Value != 7 {
+		t.Fatalf("non-string fields not masked: %v", m.Fields)
+	}
+	if m.Stack != "main.login([REDACTED])" {
+		t.Fatalf("stack not masked: %q", m.Stack)
+	}
+
+	hash, _ := NewRedactor(RedactConfig{Detectors: []string{"email"}, DenyKeys: []string{"token"}, Mask: MaskHash})
+	s1, _ := hash.RedactString("from bob@example.com")
+	s2, _ := hash.RedactString("from bob@example.com")
+	s3, _ := hash.RedactString("from eve@example.com")
+	if !strings.HasPrefix(s1, "from sha256:") || len(s1) != len("from sha256:")+12 || s1 != s2 || s1 == s3 {
+		t.Fatalf("unexpected hash masks %q %q %q", s1, s2, s3)
+	}
+	m = &LogMsg{Msg: "login", Fields: []Field{F("token", []byte("secret"))}}
+	if hash.Redact(m); m.Fields[0].Value != hash.maskValue("secret") {
+		t.Fatalf("deny key not hashed: %v", m.Fields)
+	}
+}
+
+func TestAdapterRedactor(t *testing.T) {
+	r, err := NewRedactor(RedactConfig{Detectors: []string{"email"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(false)
+	remote := &memoryWriter{}
+	if err := bl.Attach("remote", remote); err != nil {
+		t.Fatal(err)
+	}
+	if err := bl.SetAdapterRedactor("remote", r); err != nil {
+		t.Fatal(err)
+	}
+	if err := bl.SetAdapterRedactor("missing", r); err == nil {
+		t.Fatal("redactor set on an unknown adapter")
+	}
+
+	bl.With("user", "bob@example.com").Info("signup from bob@example.com")
+	if lm := remote.last(); lm.Msg != "signup from [REDACTED]" || lm.Fields[0].Value != redactedText {
+		t.Fatalf("remote output not masked: %q %v", lm.Msg, lm.Fields)
+	}
+	if lm := mw.last(); lm.Msg != "signup from bob@example.com" || lm.Fields[0].Value != "bob@example.com" {
+		t.Fatalf("redaction leaked into other outputs: %q %v", lm.Msg, lm.Fields)
+	}
+	if r.Count() != 2 {
+		t.Fatalf("unexpected count %d", r.Count())
+	}
+}
+
+func TestCaller(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(true)
+
+	bl.Info("hello %s", "world")
+	lm := mw.last()
+	if lm.Msg != "hello world" {
+		t.Fatalf("caller leaked into the message: %q", lm.Msg)
+	}
+	if !strings.HasSuffix(lm.FilePath, "loguru_test.go") || !strings.HasSuffix(lm.FuncName, ".TestCaller") || lm.Package != selfPackage {
+		t.Fatalf("unexpected caller %s:%d %s %s", lm.FilePath, lm.LineNumber, lm.FuncName, lm.Package)
+	}
+	caller := lm.Caller(false, true)
+	if want := "loguru_test.go:" + strconv.Itoa(lm.LineNumber) + " loguru.TestCaller"; caller != want {
+		t.Fatalf("unexpected caller text %q", caller)
+	}
+
+	m := *lm
+	callerOptions{Caller: "none"}.apply(&m)
+	if out := m.NormalFormat(); strings.Contains(out, "loguru_test.go") {
+		t.Fatalf("caller shown with caller none: %q", out)
+	}
+	if out := lm.NormalFormat(); !strings.Contains(out, "[loguru_test.go:") {
+		t.Fatalf("caller missing: %q", out)
+	}
+
+	out := (&JSONLogFormatter{}).Format(lm)
+	var entry struct {
+		Msg    string
+		Caller struct {
+			File     string
+			Line     int
+			Function string
+			Package  string
+		}
+	}
+	if err := json.Unmarshal([]byte(out), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Msg != "hello world" || entry.Caller.File != "loguru_test.go" || entry.Caller.Line != lm.LineNumber || entry.Caller.Package != selfPackage {
+		t.Fatalf("unexpected json %s", out)
+	}
+}
+
+func logViaHelper(bl *Loguru, msg string) {
+	Helper()
+	bl.Warn(msg)
+}
+
+func TestCallerSkip(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.EnableFuncCallDepth(true)
+
+	_, _, line, _ := runtime.Caller(0)
+	logViaHelper(bl, "via helper")
+	if got := mw.last().LineNumber; got != line+1 {
+		t.Fatalf("helper not skipped: line %d, want %d", got, line+1)
+	}
+
+	wrap := func(msg string) { bl.WithCallerSkip(1).Info(msg) }
+	_, _, line, _ = runtime.Caller(0)
+	wrap("via wrapper")
+	if got := mw.last().LineNumber; got != line+1 {
+		t.Fatalf("wrapper not skipped: line %d, want %d", got, line+1)
+	}
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	restore := RedirectStdLog(bl, LevelNotice)
+	defer restore()
+	SetStdLogPrefixLevel("[DB] ", LevelDebug)
+
+	cases := []struct {
+		line  string
+		level int
+		msg   string
+	}{
+		{"plain line", LevelNotice, "plain line"},
+		{"[ERROR] disk failed", LevelError, "disk failed"},
+		{"warn: slow query", LevelWarn, "slow query"},
+		{"[DB] connected", LevelDebug, "[DB] connected"},
+		{"[DB] [ERROR] lost", LevelError, "[DB] lost"},
+		{"http: not a level", LevelNotice, "http: not a level"},
+	}
+	for _, c := range cases {
+		log.Print(c.line)
+		lm := mw.last()
+		if lm.Level != c.level || lm.Msg != c.msg {
+			t.Fatalf("%q: got level %d msg %q", c.line, lm.Level, lm.Msg)
+		}
+		if !strings.HasSuffix(lm.FilePath, "loguru_test.go") {
+			t.Fatalf("%q: caller %s is not the log call", c.line, lm.FilePath)
+		}
+	}
+
+	GetLogger("app").SetOutput(bl)
+	GetLogger("app").Print("ALERT: ready")
+	if lm := mw.last(); lm.Level != LevelAlert || lm.Msg != "[APP] ready" {
+		t.Fatalf("unexpected message %d %q", lm.Level, lm.Msg)
+	}
+	GetLogger("app").SetOutput(logger)
+
+	w := &stdLogWriter{l: bl, defaultLevel: LevelInfo}
+	echoes := 0
+	_, _ = bl.AddFunc(func(lm *LogMsg) error {
+		echoes++
+		_, err := w.Write([]byte("echo\n"))
+		return err
+	})
+	_, _ = w.Write([]byte("once\n"))
+	if echoes != 1 || mw.last().Msg != "once" {
+		t.Fatalf("re-entrant write logged: %d echoes, last %q", echoes, mw.last().Msg)
+	}
+}
+
+func TestStdLogConcurrentWriters(t *testing.T) {
+	bl := NewLogger(0)
+	bl.EnableFuncCallDepth(false)
+	var logged int64
+	_, _ = bl.AddFunc(func(lm *LogMsg) error {
+		time.Sleep(50 * time.Microsecond)
+		atomic.AddInt64(&logged, 1)
+		return nil
+	})
+	w := &stdLogWriter{l: bl, defaultLevel: LevelInfo}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Each *log.Logger has its own mutex, so their writes overlap.
+			l := log.New(w, fmt.Sprintf("[g%d] ", i), 0)
+			for j := 0; j < 100; j++ {
+				l.Print("line")
+			}
+		}(i)
+	}
+	wg.Wait()
+	if n := atomic.LoadInt64(&logged); n != 800 {
+		t.Fatalf("expected 800 lines, got %d", n)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	w := bl.Writer(LevelWarn, WriterPrefix("[cmd] "), WriterField("pid", 7))
+
+	fmt.Fprint(w, "first\r\nsec")
+	fmt.Fprint(w, "ond\n\nthi")
+	if len(mw.msgs) != 2 || mw.msgs[0].Msg != "[cmd] first" || mw.msgs[1].Msg != "[cmd] second" {
+		t.Fatalf("unexpected lines: %d", len(mw.msgs))
+	}
+	if lm := mw.last(); lm.Level != LevelWarn || len(lm.Fields) != 1 || lm.Fields[0].Key != "pid" {
+		t.Fatalf("unexpected message %d %v", lm.Level, lm.Fields)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if lm := mw.last(); lm.Msg != "[cmd] thi" {
+		t.Fatalf("partial line not flushed: %q", lm.Msg)
+	}
+	if _, err := w.Write([]byte("late\n")); err == nil {
+		t.Fatal("write after Close succeeded")
+	}
+
+	bl.EnableFuncCallDepth(true)
+	_, _, line, _ := runtime.Caller(0)
+	w = bl.Writer(LevelInfo)
+	log.New(w, "", 0).Print("via log")
+	if lm := mw.last(); lm.Msg != "via log" || !strings.HasSuffix(lm.FuncName, ".TestWriter") || lm.LineNumber != line+1 {
+		t.Fatalf("unexpected caller %s:%d %s", lm.FilePath, lm.LineNumber, lm.FuncName)
+	}
+}
+
+func TestInput(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	SetInput(strings.NewReader("John Smith\nsecret\nmaybe\nY\nblue\n7\nabc\n42\n"))
+	defer SetInput(os.Stdin)
+
+	if s, err := bl.InputLine("name: "); err != nil || s != "John Smith" {
+		t.Fatalf("InputLine = %q, %v", s, err)
+	}
+	if lm := mw.last(); lm.Level != LevelInput || lm.Msg != "name: " {
+		t.Fatalf("unexpected prompt %d %q", lm.Level, lm.Msg)
+	}
+	if s, err := bl.InputPassword("password: "); err != nil || s != "secret" {
+		t.Fatalf("InputPassword = %q, %v", s, err)
+	}
+	if ok, err := bl.Confirm("Proceed?"); err != nil || !ok {
+		t.Fatalf("Confirm = %v, %v", ok, err)
+	}
+	if i, err := bl.Choose("color", []string{"red", "Blue"}); err != nil || i != 1 {
+		t.Fatalf("Choose = %d, %v", i, err)
+	}
+	if lm := mw.msgs[len(mw.msgs)-2]; lm.Level != LevelInfo || lm.Msg != "  2) Blue" {
+		t.Fatalf("unexpected option %d %q", lm.Level, lm.Msg)
+	}
+
+	isNumber := func(s string) error {
+		if _, err := strconv.Atoi(s); err != nil {
+			return fmt.Errorf("%q is not a number", s)
+		}
+		return nil
+	}
+	if s, err := bl.InputValidated("count: ", isNumber, 0); err != nil || s != "7" {
+		t.Fatalf("InputValidated = %q, %v", s, err)
+	}
+	if s, err := bl.InputValidated("count: ", isNumber, 1); err != nil || s != "42" {
+		t.Fatalf("InputValidated = %q, %v", s, err)
+	}
+	if _, err := bl.InputLine("more: "); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.Close()
+	fmt.Fprint(pw, "yes\nrest\n")
+	pw.Close()
+	SetInput(pr)
+	if s, err := bl.InputLine("file: "); err != nil || s != "yes" {
+		t.Fatalf("InputLine = %q, %v", s, err)
+	}
+	if rest, _ := ioutil.ReadAll(pr); string(rest) != "rest\n" {
+		t.Fatalf("input read past the line: %q left", rest)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	interval := progressLogInterval
+	progressLogInterval = 0
+	defer func() { progressLogInterval = interval }()
+
+	p := newProgress(bl, "copy", 10, false)
+	p.Add(-3)
+	if s := p.text(true); !strings.Contains(s, "["+strings.Repeat(" ", progressBarWidth)+"]   0%") {
+		t.Fatalf("unexpected bar for a negative count %q", s)
+	}
+	p.Add(8)
+	if lm := mw.last(); lm.Level != LevelInfo || !strings.HasPrefix(lm.Msg, "copy 50% 5/10") {
+		t.Fatalf("unexpected progress line %q", lm.Msg)
+	}
+	p.Done()
+	if lm := mw.last(); lm.Level != LevelSuccess || !strings.HasPrefix(lm.Msg, "copy done: 5/10") {
+		t.Fatalf("unexpected done line %q", lm.Msg)
+	}
+
+	var out strings.Builder
+	statusLine.Lock()
+	stdout := statusLine.out
+	statusLine.out = &out
+	statusLine.Unlock()
+	defer func() {
+		statusLine.Lock()
+		statusLine.out = stdout
+		statusLine.Unlock()
+	}()
+
+	bar := newProgress(bl, "sync", 4, true)
+	bar.Set(2)
+	console := newConsole()
+	console.lg = newLogWriter(&out)
+	console.Colorful = false
+	console.formatter = &PatternLogFormatter{Pattern: "%m"}
+	out.Reset()
+	_ = console.WriteMsg(&LogMsg{Level: LevelInfo, Msg: "hello", When: time.Now()})
+	want := clearLine + "hello\n" + clearLine + "sync [==============>               ]  50% 2/4"
+	if got := out.String(); !strings.HasPrefix(got, want) {
+		t.Fatalf("unexpected console output %q", got)
+	}
+	bar.Done()
+	statusLine.Lock()
+	defer statusLine.Unlock()
+	if len(statusLine.bars) != 0 || statusLine.stop != nil {
+		t.Fatal("bar still registered after Done")
+	}
+}
+
+func TestTimedAndScope(t *testing.T) {
+	bl, mw := newMemoryLogger()
+
+	bl.Timed("fast")()
+	if lm := mw.last(); lm.Level != LevelInfo || !strings.HasPrefix(lm.Msg, "fast took ") || lm.Fields[0].Key != "elapsed" {
+		t.Fatalf("unexpected timing %d %q", lm.Level, lm.Msg)
+	}
+	done := bl.Timed("slow", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	done()
+	if lm := mw.last(); lm.Level != LevelWarn {
+		t.Fatalf("slow operation logged at %d", lm.Level)
+	}
+
+	outer := bl.Scope("import")
+	inner := outer.Scope("users")
+	inner.Info("row %d", 1)
+	lm := mw.last()
+	if lm.Scope != "import/users" || lm.ScopeDepth != 2 || lm.ScopeElapsed <= 0 {
+		t.Fatalf("unexpected scope %q %d %v", lm.Scope, lm.ScopeDepth, lm.ScopeElapsed)
+	}
+	if _, msg, _ := ProcessSpace(lm); strings.TrimPrefix(msg, " ") != "    row 1" {
+		t.Fatalf("message not indented: %q", msg)
+	}
+	inner.End()
+	if lm := mw.last(); !strings.HasPrefix(lm.Msg, "exit users after") || lm.Scope != "import/users" {
+		t.Fatalf("unexpected exit %q", lm.Msg)
+	}
+	outer.Info("back")
+	if lm := mw.last(); lm.Scope != "import" || lm.ScopeDepth != 1 {
+		t.Fatalf("unexpected scope %q", lm.Scope)
+	}
+}
+
+func TestSettings(t *testing.T) {
+	bl := NewLogger(Console)
+	for _, v := range []struct {
+		apply func(string) error
+		value string
+	}{
+		{bl.applyLevel, "warn"},
+		{bl.applyFormat, "json"},
+		{bl.applyColor, "false"},
+	} {
+		if err := v.apply(v.value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := bl.applyLevel("loud"); err == nil {
+		t.Fatal("invalid level accepted")
+	}
+	if bl.GetLevel() != LevelWarn {
+		t.Fatalf("level not applied: %d", bl.GetLevel())
+	}
+	if err := bl.setLogger(AdapterConsole, bl.consoleConfig); err != nil {
+		t.Fatal(err)
+	}
+	c := bl.outputs[0].Logger.(*consoleWriter)
+	if _, ok := c.formatter.(*JSONLogFormatter); !ok || c.Colorful {
+		t.Fatalf("console config not applied: %s", bl.consoleConfig)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	RegisterFlags(fs)
+	level := logger.GetLevel()
+	defer logger.SetLevel(level)
+	if err := fs.Parse([]string{"-log-level", "error"}); err != nil {
+		t.Fatal(err)
+	}
+	if logger.GetLevel() != LevelError || fs.Lookup("log-level").Value.String() != "error" {
+		t.Fatalf("flag not applied: %d", logger.GetLevel())
+	}
+	if err := fs.Parse([]string{"-log-async=maybe"}); err == nil {
+		t.Fatal("invalid async value accepted")
+	}
+}
+
+func TestFlagsOverrideEnv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "loguru")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	saved := logger
+	logger = NewLogger(0)
+	stdOnce = sync.Once{}
+	defer func() {
+		logger.Close()
+		logger = saved
+		stdOnce = sync.Once{}
+		stdOnce.Do(func() {})
+		for _, s := range settings {
+			s.fromFlag = false
+		}
+	}()
+	os.Setenv("LOGURU_FILE", dir+"/env.log")
+	os.Setenv("LOGURU_ASYNC", "1")
+	defer os.Unsetenv("LOGURU_FILE")
+	defer os.Unsetenv("LOGURU_ASYNC")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	RegisterFlags(fs)
+	if err := fs.Parse([]string{"-log-file", dir + "/flag.log", "-log-async=false"}); err != nil {
+		t.Fatal(err)
+	}
+	bl := std()
+	if bl.asynchronous || len(bl.outputs) != 1 || bl.outputs[0].Logger.(*fileLogWriter).Filename != dir+"/flag.log" {
+		t.Fatalf("env applied over flags: async %v, %d outputs", bl.asynchronous, len(bl.outputs))
+	}
+
+	if err := bl.applyFile(dir + "/other.log"); err != nil {
+		t.Fatal(err)
+	}
+	if len(bl.outputs) != 1 || bl.outputs[0].Logger.(*fileLogWriter).Filename != dir+"/other.log" {
+		t.Fatalf("file sink not replaced: %d outputs", len(bl.outputs))
+	}
+}
+
+func TestSinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "loguru")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	bl := NewLogger(0)
+	bl.EnableFuncCallDepth(false)
+	app, err := bl.Add(AdapterFile, `{"filename":"`+dir+`/app.log"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := bl.Add(AdapterFile, `{"filename":"`+dir+`/errors.log","level":3}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app == errs {
+		t.Fatal("sinks share an id")
+	}
+	bl.Info("started")
+	bl.Error("failed")
+	if err := bl.Remove(app); err != nil {
+		t.Fatal(err)
+	}
+	if err := bl.Remove(app); err == nil {
+		t.Fatal("removed a sink twice")
+	}
+	bl.Error("after remove")
+	bl.Close()
+
+	read := func(name string) string {
+		b, err := ioutil.ReadFile(dir + "/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	if s := read("app.log"); !strings.Contains(s, "started") || strings.Contains(s, "after remove") {
+		t.Fatalf("unexpected app.log:\n%s", s)
+	}
+	if s := read("errors.log"); strings.Contains(s, "started") || strings.Count(s, "\n") != 2 {
+		t.Fatalf("unexpected errors.log:\n%s", s)
+	}
+
+	if _, err := bl.Add("nope", ""); err == nil {
+		t.Fatal("unknown adapter accepted")
+	}
+}
+
+type flushCloser struct {
+	strings.Builder
+	flushed, closed int
+}
+
+func (f *flushCloser) Flush() error {
+	f.flushed++
+	return nil
+}
+
+func (f *flushCloser) Close() error {
+	f.closed++
+	return nil
+}
+
+func TestWriterAndFuncSinks(t *testing.T) {
+	bl := NewLogger(0)
+	bl.EnableFuncCallDepth(false)
+	w := &flushCloser{}
+	wid, err := bl.AddWriter(w, SinkLevel(LevelWarn), SinkFormatter(&PatternLogFormatter{Pattern: "%T: %m"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	fid, err := bl.AddFunc(func(lm *LogMsg) error {
+		got = append(got, lm.Msg)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bl.Info("info")
+	bl.Error("boom")
+	bl.Flush()
+	if w.String() != "error: boom\n" || w.flushed != 1 {
+		t.Fatalf("unexpected writer output %q, flushed %d", w.String(), w.flushed)
+	}
+	if len(got) != 2 || got[1] != "boom" {
+		t.Fatalf("unexpected func sink messages %v", got)
+	}
+
+	if err := bl.Remove(wid); err != nil {
+		t.Fatal(err)
+	}
+	if w.closed != 1 {
+		t.Fatal("writer not closed on Remove")
+	}
+	_ = bl.Remove(fid)
+	if _, err := bl.AddWriter(nil); err == nil {
+		t.Fatal("nil writer accepted")
+	}
+}
+
+func TestOpt(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.SetLevel(LevelInfo)
+
+	called := 0
+	expensive := func() interface{} {
+		called++
+		return "dump"
+	}
+	bl.Opt(Lazy()).Debug("state %v", expensive)
+	bl.Opt(Lazy()).Info("state %v", expensive)
+	if called != 1 || mw.last().Msg != "state dump" {
+		t.Fatalf("lazy argument called %d times, message %q", called, mw.last().Msg)
+	}
+
+	bl.Opt(Exception(errors.New("bad input"))).Warn("rejected")
+	if lm := mw.last(); lm.Err == nil || !strings.Contains(lm.Stack, "TestOpt") {
+		t.Fatalf("exception not captured: %v %q", lm.Err, lm.Stack)
+	}
+
+	bl.EnableFuncCallDepth(true)
+	wrap := func() { bl.Opt(Depth(1)).Info("deep") }
+	_, _, line, _ := runtime.Caller(0)
+	wrap()
+	if got := mw.last().LineNumber; got != line+1 {
+		t.Fatalf("depth ignored: line %d, want %d", got, line+1)
+	}
+
+	var out strings.Builder
+	if _, err := bl.AddWriter(&out); err != nil {
+		t.Fatal(err)
+	}
+	bl.Opt(Raw()).Info("%d%% done\r", 50)
+	if out.String() != "50% done\r" || !mw.last().Raw {
+		t.Fatalf("raw message formatted: %q", out.String())
+	}
 
-	// 写入文件
-	//logger := NewLogger(FileLog)
-	//logger.Debug("1111")
-	//logger.Info("2222")
-	//logger.Warning("3333")
-	//logger.Success("4444")
-	//logger.Emergency("5555")
-
-	// 在线输出
-	//logger := NewLogger(3)
-	//logger.Debug("嘿嘿嘿")
-	x := Input("请输入: ")
-	log.Println(x)
-
-	y := Input("请输入2: ")
-	log.Println(y)
+	if got := logTemplate("a", 1, 2); got != "a %v %v" {
+		t.Fatalf("unexpected template %q", got)
+	}
+	if got := fmt.Sprintf(logTemplate(errors.New("100%"), 1), 1); got != "100% 1" {
+		t.Fatalf("unexpected template output %q", got)
+	}
 }
@@ -3,46 +3,125 @@ package loguru
 import (
 	"fmt"
 	"path"
+	"runtime"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type LogMsg struct {
-	Space               int
-	Level               int
-	Msg                 string
-	When                time.Time
-	FilePath            string
-	LineNumber          int
-	Args                []interface{}
-	Prefix              string
+	Space      int
+	Level      int
+	Msg        string
+	When       time.Time
+	FilePath   string
+	LineNumber int
+	FuncName   string
+	Package    string
+	Args       []interface{}
+	Prefix     string
+	Fields     []Field
+	Name       string
+	Err        error
+	Stack      string
+	// Raw messages are written as they are, without formatter, caller or
+	// trailing newline.
+	Raw                 bool
+	Scope               string
+	ScopeDepth          int
+	ScopeElapsed        time.Duration
+	format              string
+	protected           bool
 	enableFullFilePath  bool
 	enableFuncCallDepth bool
+	enableFuncName      bool
+}
+
+func (lm *LogMsg) setCaller(f runtime.Frame) {
+	lm.FilePath = f.File
+	lm.LineNumber = f.Line
+	lm.FuncName = f.Function
+	lm.Package = funcPackage(f.Function)
+	lm.enableFuncCallDepth = true
+}
+
+// Caller renders the call site as "file.go:12", with the full path when
+// fullPath is set and followed by the function name when funcName is set.
+// It is empty when no caller was recorded.
+func (lm *LogMsg) Caller(fullPath, funcName bool) string {
+	if lm.FilePath == "" {
+		return ""
+	}
+	file := lm.FilePath
+	if !fullPath {
+		_, file = path.Split(file)
+	}
+	s := file + ":" + strconv.Itoa(lm.LineNumber)
+	if funcName && lm.FuncName != "" {
+		s += " " + shortFuncName(lm.FuncName)
+	}
+	return s
+}
+
+func (lm *LogMsg) callerText() string {
+	if !lm.enableFuncCallDepth {
+		return ""
+	}
+	return lm.Caller(lm.enableFullFilePath, lm.enableFuncName)
+}
+
+// callerOptions holds the caller settings shared by the adapters' JSON
+// config: "caller" is "short" (the default), "full" or "none", and
+// "funcname" adds the function name.
+type callerOptions struct {
+	Caller   string `json:"caller"`
+	FuncName bool   `json:"funcname"`
+}
+
+func (o callerOptions) check() error {
+	switch o.Caller {
+	case "", "short", "full", "none":
+		return nil
+	}
+	return fmt.Errorf("logs: unknown caller mode %q", o.Caller)
+}
+
+func (o callerOptions) apply(lm *LogMsg) {
+	switch o.Caller {
+	case "full":
+		lm.enableFullFilePath = true
+	case "none":
+		lm.enableFuncCallDepth = false
+	}
+	lm.enableFuncName = o.FuncName
+}
+
+// shortFuncName drops the import path from a qualified function name,
+// leaving "pkg.(*T).Method".
+func shortFuncName(name string) string {
+	if i := len(name) - len(path.Base(name)); i > 0 {
+		return name[i:]
+	}
+	return name
 }
 
 func ProcessSpace(lm *LogMsg) (string, string, string) {
-	c1 := " |  "
-	switch lm.Level {
-	case 0:
-	case 2:
-		c1 = " " + c1
-	case 1, 3, 8, 9:
-		c1 = "    " + c1
-	case 4, 5:
-		c1 = "  " + c1
-	case 6:
-		c1 = "   " + c1
-	case 7:
-		c1 = "     " + c1
+	c1 := levelPadding(lm.Level) + " |  "
+	msg2 := strings.Repeat("  ", lm.ScopeDepth) + lm.Msg
+	if lm.Name != "" {
+		msg2 = "[" + lm.Name + "] " + msg2
 	}
-	msg1 := strings.Split(lm.Msg, " ")
-	msg2 := strings.Replace(lm.Msg, msg1[0], "", 1)
 
-	space := " "
-	for i := 0; i < lm.Space-len(msg1[0]); i++ {
-		space += " "
+	msg3 := ""
+	if caller := lm.callerText(); caller != "" {
+		caller = "[" + caller + "]"
+		space := " "
+		for i := 0; i < lm.Space-len(caller); i++ {
+			space += " "
+		}
+		msg3 = fmt.Sprintf("%s%s ▶  ", caller, space)
+		msg2 = " " + msg2
 	}
-	msg3 := fmt.Sprintf("%s%s ▶  ", msg1[0], space)
 	return c1, msg2, msg3
 }
 
@@ -54,17 +133,8 @@ func (lm *LogMsg) ColorStyleFormat() string {
 	}
 
 	c1, msg2, msg3 := ProcessSpace(lm)
-	msg = lm.Prefix + colorsMap["red"](c1) + fileColor(msg3) + colors[lm.Level](msg2)
-
-	if lm.enableFuncCallDepth {
-		filePath := lm.FilePath
-		if !lm.enableFullFilePath {
-			_, filePath = path.Split(filePath)
-		}
-		msg = fmt.Sprintf("[%s:%d] %s", filePath, lm.LineNumber, msg)
-	}
-
-	msg = levelPrefix[lm.Level] + " " + msg
+	msg = lm.Prefix + colorsMap["red"](c1) + fileColor(msg3) + levelColor(lm.Level)(msg2) + formatFields(lm.Fields, colorsMap["cyan"]) + lm.errorField(colorsMap["cyan"])
+	msg = levelPrefix(lm.Level) + " " + msg + lm.errorDetails()
 	return msg
 }
 
@@ -76,15 +146,6 @@ func (lm *LogMsg) NormalFormat() string {
 	}
 
 	c1, msg2, msg3 := ProcessSpace(lm)
-
-	if lm.enableFuncCallDepth {
-		filePath := lm.FilePath
-		if !lm.enableFullFilePath {
-			_, filePath = path.Split(filePath)
-		}
-		msg = fmt.Sprintf("[%s:%d] %s", filePath, lm.LineNumber, msg)
-	}
-
-	msg = "| " + levelPrefix[lm.Level] + c1 + msg3 + msg2
+	msg = "| " + levelPrefix(lm.Level) + c1 + msg3 + msg2 + formatFields(lm.Fields, nil) + lm.errorField(nil) + lm.errorDetails()
 	return msg
 }
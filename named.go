@@ -0,0 +1,118 @@
+package loguru
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Named returns the logger called name below bl in the dot-separated
+// hierarchy, creating it and its ancestors on first use. A named logger
+// shares the outputs of the root and inherits the level of its closest
+// ancestor that has one set.
+func (bl *Loguru) Named(name string) *Loguru {
+	node := bl.namedNode(name)
+	if node != bl && len(bl.fields) > 0 {
+		return node.withFields(bl.fields)
+	}
+	return node
+}
+
+// namedNode returns the registered logger called name below bl, without
+// the fields of bl, so that setting its level affects every user of name.
+func (bl *Loguru) namedNode(name string) *Loguru {
+	name = strings.Trim(name, ".")
+	if name == "" {
+		return bl
+	}
+	if bl.name != "" {
+		name = bl.name + "." + name
+	}
+	return bl.root().namedLogger(name)
+}
+
+func (bl *Loguru) Name() string {
+	return bl.name
+}
+
+func (bl *Loguru) namedLogger(name string) *Loguru {
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	if bl.named == nil {
+		bl.named = make(map[string]*Loguru)
+	}
+	parent := bl
+	full := ""
+	for _, part := range strings.Split(name, ".") {
+		if part == "" {
+			continue
+		}
+		if full != "" {
+			full += "."
+		}
+		full += part
+		node, ok := bl.named[full]
+		if !ok {
+			node = &Loguru{parent: parent, name: full}
+			bl.named[full] = node
+		}
+		parent = node
+	}
+	return parent
+}
+
+// lookupNamed returns the named logger called name below bl without
+// creating it.
+func (bl *Loguru) lookupNamed(name string) (*Loguru, bool) {
+	name = strings.Trim(name, ".")
+	if name == "" {
+		return bl, true
+	}
+	if bl.name != "" {
+		name = bl.name + "." + name
+	}
+	root := bl.root()
+	root.lock.Lock()
+	defer root.lock.Unlock()
+	node, ok := root.named[name]
+	return node, ok
+}
+
+// SetLevels applies a comma separated level spec such as
+// "info,db=warn,db.sql=debug"; an entry without a name sets bl itself.
+func (bl *Loguru) SetLevels(spec string) error {
+	type entry struct {
+		l     *Loguru
+		level int
+	}
+	var entries []entry
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		target, levelName := bl, item
+		if i := strings.LastIndex(item, "="); i >= 0 {
+			levelName = item[i+1:]
+			if name := strings.TrimSpace(item[:i]); name != "" && name != "*" {
+				target = bl.namedNode(name)
+			}
+		}
+		level, err := ParseLevel(levelName)
+		if err != nil {
+			return fmt.Errorf("logs: invalid level spec %q: %v", item, err)
+		}
+		entries = append(entries, entry{target, level})
+	}
+	for _, e := range entries {
+		e.l.SetLevel(e.level)
+	}
+	return nil
+}
+
+func Named(name string) *Loguru {
+	return std().Named(name)
+}
+
+func SetLevels(spec string) error {
+	return std().SetLevels(spec)
+}
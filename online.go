@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 )
 
 type OnlineLogger struct {
@@ -21,7 +22,9 @@ type OnlineLogger struct {
 	Host      string `json:"host"`
 	App       string `json:"app"`
 	Formatter string `json:"formatter"`
+	Level     int32  `json:"level"`
 	formatter LogFormatter
+	callerOptions
 }
 
 func (o *OnlineLogger) Format(lm *LogMsg) string {
@@ -36,6 +39,9 @@ func (o *OnlineLogger) Init(config string) error {
 	if err != nil {
 		return err
 	}
+	if err := o.check(); err != nil {
+		return err
+	}
 	c, err := net.Dial("tcp", o.Host)
 	if err != nil {
 		return err
@@ -45,12 +51,24 @@ func (o *OnlineLogger) Init(config string) error {
 }
 
 func (o *OnlineLogger) WriteMsg(lm *LogMsg) error {
-	msg := o.formatter.Format(lm)
-	message := append([]byte(fmt.Sprintf("+msg|%s|%s|%s", o.App, levelNames[lm.Level], msg)), 0)
+	if !levelEnabled(lm.Level, o.GetLevel()) {
+		return nil
+	}
+	o.apply(lm)
+	msg := formatMsg(o.formatter, lm)
+	message := append([]byte(fmt.Sprintf("+msg|%s|%s|%s", o.App, LevelName(lm.Level), msg)), 0)
 	_, err := o.conn.Write(message)
 	return err
 }
 
+func (o *OnlineLogger) SetLevel(l int) {
+	atomic.StoreInt32(&o.Level, int32(l))
+}
+
+func (o *OnlineLogger) GetLevel() int {
+	return int(atomic.LoadInt32(&o.Level))
+}
+
 func (o *OnlineLogger) SetFormatter(f LogFormatter) {
 	o.formatter = f
 }
@@ -60,14 +78,14 @@ func (o *OnlineLogger) Destroy() {
 }
 
 func (o *OnlineLogger) Flush() {
-	for _, level := range levelNames {
-		message := append([]byte(fmt.Sprintf("-input|%s|%s", o.App, level)))
+	for _, level := range levels() {
+		message := []byte(fmt.Sprintf("-input|%s|%s", o.App, LevelName(level)))
 		_, _ = o.conn.Write(message)
 	}
 }
 
 func NewOnlineLogger() Logger {
-	cw := &OnlineLogger{}
+	cw := &OnlineLogger{Level: LevelDebug}
 	cw.formatter = cw
 	return cw
 }
@@ -0,0 +1,86 @@
+package loguru
+
+import "runtime"
+
+type callOptions struct {
+	lazy  bool
+	raw   bool
+	stack bool
+	// frame, when set, is reported as the caller instead of looking it up.
+	frame *runtime.Frame
+}
+
+type CallOption func(bl *Loguru)
+
+// Lazy makes arguments of type func() interface{} be called only when the
+// message is actually written, to format their result:
+//
+//	logger.Opt(loguru.Lazy()).Debug("state: %v", func() interface{} { return dump() })
+func Lazy() CallOption {
+	return func(bl *Loguru) {
+		bl.opts.lazy = true
+	}
+}
+
+// Depth reports the caller n frames further up the stack, like
+// WithCallerSkip.
+func Depth(n int) CallOption {
+	return func(bl *Loguru) {
+		bl.callerSkip += n
+	}
+}
+
+// Exception attaches err like WithError and always records the stack, even
+// when err carries none and stack traces are off for the level.
+func Exception(err error) CallOption {
+	return func(bl *Loguru) {
+		bl.err = err
+		bl.opts.stack = true
+	}
+}
+
+// Raw writes the message as it is, bypassing every formatter.
+func Raw() CallOption {
+	return func(bl *Loguru) {
+		bl.opts.raw = true
+	}
+}
+
+// Opt returns a child logger whose messages use opts.
+func (bl *Loguru) Opt(opts ...CallOption) *Loguru {
+	child := bl.withFields(nil)
+	for _, opt := range opts {
+		opt(child)
+	}
+	return child
+}
+
+func evalLazy(v []interface{}) []interface{} {
+	var out []interface{}
+	for i, a := range v {
+		f, ok := a.(func() interface{})
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = append([]interface{}(nil), v...)
+		}
+		out[i] = f()
+	}
+	if out == nil {
+		return v
+	}
+	return out
+}
+
+// formatMsg formats lm with f unless lm is raw.
+func formatMsg(f LogFormatter, lm *LogMsg) string {
+	if lm.Raw {
+		return lm.Msg
+	}
+	return f.Format(lm)
+}
+
+func Opt(opts ...CallOption) *Loguru {
+	return std().Opt(opts...)
+}
@@ -0,0 +1,165 @@
+package loguru
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy decides what an Async logger does when its queue is full.
+type OverflowPolicy int
+
+const (
+	OverflowBlock OverflowPolicy = iota
+	OverflowDropNewest
+	OverflowDropOldest
+	OverflowBlockTimeout
+)
+
+const defaultOverflowTimeout = 100 * time.Millisecond
+
+type AsyncStats struct {
+	Enqueued      uint64
+	Written       uint64
+	Dropped       uint64
+	HighWaterMark int64
+}
+
+// asyncCounters is the first field of Loguru so its 64-bit words stay
+// aligned for sync/atomic on 32-bit platforms.
+type asyncCounters struct {
+	enqueued      uint64
+	written       uint64
+	dropped       uint64
+	highWaterMark int64
+	// protected counts queued messages covered by the never-drop level.
+	protected int64
+}
+
+// SetOverflowPolicy chooses how a full Async queue is handled. timeout only
+// applies to OverflowBlockTimeout and defaults to 100ms.
+func (bl *Loguru) SetOverflowPolicy(p OverflowPolicy, timeout ...time.Duration) {
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	bl.overflow = p
+	bl.overflowTimeout = defaultOverflowTimeout
+	if len(timeout) > 0 && timeout[0] > 0 {
+		bl.overflowTimeout = timeout[0]
+	}
+}
+
+// SetNeverDropLevel makes messages at level or more severe block instead of
+// being dropped whatever the overflow policy. Use -1 to protect nothing.
+func (bl *Loguru) SetNeverDropLevel(level int) {
+	bl = bl.root()
+	bl.lock.Lock()
+	bl.neverDropLevel = level
+	bl.lock.Unlock()
+}
+
+func (bl *Loguru) AsyncStats() AsyncStats {
+	bl = bl.root()
+	return AsyncStats{
+		Enqueued:      atomic.LoadUint64(&bl.counters.enqueued),
+		Written:       atomic.LoadUint64(&bl.counters.written),
+		Dropped:       atomic.LoadUint64(&bl.counters.dropped),
+		HighWaterMark: atomic.LoadInt64(&bl.counters.highWaterMark),
+	}
+}
+
+func (bl *Loguru) enqueue(lm *LogMsg) {
+	bl.lock.Lock()
+	policy, timeout := bl.overflow, bl.overflowTimeout
+	if levelEnabled(lm.Level, bl.neverDropLevel) {
+		policy = OverflowBlock
+		lm.protected = true
+	}
+	bl.lock.Unlock()
+
+	if lm.protected {
+		bl.dropMu.Lock()
+		atomic.AddInt64(&bl.counters.protected, 1)
+		bl.dropMu.Unlock()
+	}
+
+	switch policy {
+	case OverflowDropNewest:
+		select {
+		case bl.msgChan <- lm:
+		default:
+			bl.drop(lm)
+			return
+		}
+	case OverflowDropOldest:
+		if !bl.dropOldest(lm) {
+			// A protected message is queued, so waiting for room is the only
+			// way to keep it and its order.
+			bl.msgChan <- lm
+		}
+	case OverflowBlockTimeout:
+		select {
+		case bl.msgChan <- lm:
+		default:
+			t := time.NewTimer(timeout)
+			select {
+			case bl.msgChan <- lm:
+				t.Stop()
+			case <-t.C:
+				bl.drop(lm)
+				return
+			}
+		}
+	default:
+		bl.msgChan <- lm
+	}
+
+	atomic.AddUint64(&bl.counters.enqueued, 1)
+	n := int64(len(bl.msgChan))
+	for {
+		hwm := atomic.LoadInt64(&bl.counters.highWaterMark)
+		if n <= hwm || atomic.CompareAndSwapInt64(&bl.counters.highWaterMark, hwm, n) {
+			break
+		}
+	}
+}
+
+func (bl *Loguru) drop(lm *LogMsg) {
+	atomic.AddUint64(&bl.counters.dropped, 1)
+	logMsgPool.Put(lm)
+}
+
+// dropOldest makes room for lm by dropping messages from the head of the
+// queue. It gives up without sending lm while a protected message is queued,
+// which dropMu keeps from reaching the head unnoticed.
+func (bl *Loguru) dropOldest(lm *LogMsg) bool {
+	bl.dropMu.Lock()
+	defer bl.dropMu.Unlock()
+	for {
+		select {
+		case bl.msgChan <- lm:
+			return true
+		default:
+		}
+		if atomic.LoadInt64(&bl.counters.protected) > 0 {
+			return false
+		}
+		select {
+		case old := <-bl.msgChan:
+			bl.drop(old)
+		default:
+		}
+	}
+}
+
+func (bl *Loguru) writeQueued(lm *LogMsg) {
+	if lm.protected {
+		atomic.AddInt64(&bl.counters.protected, -1)
+	}
+	bl.writeToLoggers(lm)
+	atomic.AddUint64(&bl.counters.written, 1)
+	logMsgPool.Put(lm)
+}
+
+func SetOverflowPolicy(p OverflowPolicy, timeout ...time.Duration) {
+	std().SetOverflowPolicy(p, timeout...)
+}
@@ -0,0 +1,214 @@
+package loguru
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shiena/ansicolor"
+)
+
+const (
+	progressBarWidth = 30
+	progressRedraw   = 100 * time.Millisecond
+	clearLine        = "\r\033[K"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// progressLogInterval is how often a bar logs a plain line when stdout is
+// not a terminal.
+var progressLogInterval = 5 * time.Second
+
+// statusLine is the last terminal line, where the active bars are drawn.
+// The console adapter clears it before writing a message and draws it again
+// afterwards.
+var statusLine = struct {
+	sync.Mutex
+	out    io.Writer
+	bars   []*Progress
+	drawn  bool
+	paused bool
+	stop   chan struct{}
+}{
+	out: ansicolor.NewAnsiColorWriter(os.Stdout),
+}
+
+// clearStatus and drawStatus must be called with statusLine locked.
+func clearStatus() {
+	if statusLine.drawn {
+		_, _ = io.WriteString(statusLine.out, clearLine)
+		statusLine.drawn = false
+	}
+}
+
+func drawStatus() {
+	if statusLine.paused || len(statusLine.bars) == 0 {
+		return
+	}
+	parts := make([]string, len(statusLine.bars))
+	for i, p := range statusLine.bars {
+		parts[i] = p.text(true)
+	}
+	_, _ = io.WriteString(statusLine.out, clearLine+strings.Join(parts, "  "))
+	statusLine.drawn = true
+}
+
+func redrawStatus(stop chan struct{}) {
+	t := time.NewTicker(progressRedraw)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			statusLine.Lock()
+			drawStatus()
+			statusLine.Unlock()
+		}
+	}
+}
+
+// Progress is a progress bar, or a spinner when it has no total. On a
+// terminal it is redrawn on the last line below the log output; otherwise
+// it logs a line at LevelInfo every few seconds.
+type Progress struct {
+	current int64
+	l       *Loguru
+	name    string
+	total   int64
+	started time.Time
+	tty     bool
+	mu      sync.Mutex
+	lastLog time.Time
+	done    bool
+}
+
+func (bl *Loguru) NewProgress(name string, total int64) *Progress {
+	return newProgress(bl, name, total, isTerminal(int(os.Stdout.Fd())))
+}
+
+// NewSpinner shows activity of unknown length; Add counts the work done.
+func (bl *Loguru) NewSpinner(name string) *Progress {
+	return bl.NewProgress(name, 0)
+}
+
+func newProgress(bl *Loguru, name string, total int64, tty bool) *Progress {
+	p := &Progress{l: bl, name: name, total: total, started: time.Now(), tty: tty}
+	if tty {
+		statusLine.Lock()
+		statusLine.bars = append(statusLine.bars, p)
+		statusLine.paused = false
+		if statusLine.stop == nil {
+			statusLine.stop = make(chan struct{})
+			go redrawStatus(statusLine.stop)
+		}
+		drawStatus()
+		statusLine.Unlock()
+	}
+	return p
+}
+
+func (p *Progress) Add(n int64) {
+	p.update(atomic.AddInt64(&p.current, n))
+}
+
+func (p *Progress) Set(n int64) {
+	atomic.StoreInt64(&p.current, n)
+	p.update(n)
+}
+
+func (p *Progress) update(n int64) {
+	if p.tty {
+		return
+	}
+	p.mu.Lock()
+	due := !p.done && time.Since(p.lastLog) >= progressLogInterval
+	if due {
+		p.lastLog = time.Now()
+	}
+	p.mu.Unlock()
+	if due {
+		_ = p.l.writeMsg(LevelInfo, p.text(false))
+	}
+}
+
+// Done removes the bar and logs how long the work took at LevelSuccess.
+func (p *Progress) Done() {
+	p.mu.Lock()
+	if p.done {
+		p.mu.Unlock()
+		return
+	}
+	p.done = true
+	p.mu.Unlock()
+
+	if p.tty {
+		statusLine.Lock()
+		for i, b := range statusLine.bars {
+			if b == p {
+				statusLine.bars = append(statusLine.bars[:i:i], statusLine.bars[i+1:]...)
+				break
+			}
+		}
+		clearStatus()
+		if len(statusLine.bars) == 0 && statusLine.stop != nil {
+			close(statusLine.stop)
+			statusLine.stop = nil
+		}
+		drawStatus()
+		statusLine.Unlock()
+	}
+	_ = p.l.writeMsg(LevelSuccess, "%s done: %s in %s", p.name, p.count(), p.elapsed())
+}
+
+func (p *Progress) elapsed() time.Duration {
+	return time.Since(p.started).Round(progressRedraw)
+}
+
+func (p *Progress) count() string {
+	n := atomic.LoadInt64(&p.current)
+	if p.total <= 0 {
+		return fmt.Sprint(n)
+	}
+	return fmt.Sprintf("%d/%d", n, p.total)
+}
+
+func (p *Progress) text(tty bool) string {
+	if p.total <= 0 {
+		s := fmt.Sprintf("%s %s %s", p.name, p.count(), p.elapsed())
+		if tty {
+			frame := int(time.Since(p.started)/progressRedraw) % len(spinnerFrames)
+			s = spinnerFrames[frame] + " " + s
+		}
+		return s
+	}
+	n := atomic.LoadInt64(&p.current)
+	if n < 0 {
+		n = 0
+	} else if n > p.total {
+		n = p.total
+	}
+	pct := int(n * 100 / p.total)
+	if !tty {
+		return fmt.Sprintf("%s %d%% %s %s", p.name, pct, p.count(), p.elapsed())
+	}
+	filled := int(n * progressBarWidth / p.total)
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+	if filled > 0 && filled < progressBarWidth {
+		bar = bar[:filled-1] + ">" + bar[filled:]
+	}
+	return fmt.Sprintf("%s [%s] %3d%% %s %s", p.name, bar, pct, p.count(), p.elapsed())
+}
+
+func NewProgress(name string, total int64) *Progress {
+	return std().NewProgress(name, total)
+}
+
+func NewSpinner(name string) *Progress {
+	return std().NewSpinner(name)
+}
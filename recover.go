@@ -0,0 +1,141 @@
+package loguru
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const LevelFatal = LevelDebug + 1
+
+var osExit = os.Exit
+
+var exitHooks = struct {
+	sync.Mutex
+	hooks []func()
+}{}
+
+// RegisterExitHook adds fn to the functions run by Fatal before the process
+// exits, in registration order.
+func RegisterExitHook(fn func()) {
+	if fn == nil {
+		panic("logs: RegisterExitHook provide is nil")
+	}
+	exitHooks.Lock()
+	exitHooks.hooks = append(exitHooks.hooks, fn)
+	exitHooks.Unlock()
+}
+
+func runExitHooks() {
+	exitHooks.Lock()
+	hooks := append([]func(){}, exitHooks.hooks...)
+	exitHooks.Unlock()
+	for _, fn := range hooks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					_, _ = fmt.Fprintf(os.Stderr, "loguru: exit hook panicked: %v\n", r)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+// SetRepanic makes Recover and Catch panic again after logging a panic.
+func (bl *Loguru) SetRepanic(b bool) {
+	bl.root().repanic = b
+}
+
+// Recover logs a panic at LevelCritical with its stack. It must be deferred
+// directly: defer logger.Recover().
+func (bl *Loguru) Recover() {
+	if r := recover(); r != nil {
+		bl.handlePanic(r)
+	}
+}
+
+// Catch runs fn and logs at LevelCritical the error it returns or the panic
+// it raises, which is returned as an error.
+func (bl *Loguru) Catch(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = bl.handlePanic(r)
+		}
+	}()
+	if err = fn(); err != nil {
+		frames := callerFrames(false)
+		stack := errorStack(err)
+		if stack == "" {
+			stack = formatFrames(frames)
+		}
+		bl.logCaught(err, stack, frames, "caught error")
+	}
+	return err
+}
+
+func (bl *Loguru) handlePanic(r interface{}) error {
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("%v", r)
+	}
+	frames := callerFrames(true)
+	bl.logCaught(err, formatFrames(frames), frames, "panic: %v", r)
+	if bl.root().repanic {
+		panic(r)
+	}
+	return err
+}
+
+func (bl *Loguru) logCaught(err error, stack string, frames []runtime.Frame, format string, v ...interface{}) {
+	if !bl.enabled(LevelCritical) {
+		return
+	}
+	lm := &LogMsg{Level: LevelCritical, Msg: fmt.Sprintf(format, v...), When: time.Now(), Fields: bl.fields, Name: bl.name, Err: err, Stack: stack, format: format}
+	if bl.root().enableFuncCallDepth && len(frames) > 0 {
+		lm.setCaller(frames[0])
+	}
+	bl.emit(lm)
+}
+
+// Fatal logs at LevelFatal, runs the exit hooks, drains and closes every
+// output and exits with status 1.
+func (bl *Loguru) Fatal(format string, v ...interface{}) {
+	_ = bl.writeMsg(LevelFatal, format, v...)
+	bl.exit(1)
+}
+
+func (bl *Loguru) Fatalf(format string, v ...interface{}) {
+	_ = bl.writeMsg(LevelFatal, format, v...)
+	bl.exit(1)
+}
+
+func (bl *Loguru) exit(code int) {
+	runExitHooks()
+	bl.Close()
+	osExit(code)
+}
+
+func Recover() {
+	if r := recover(); r != nil {
+		std().handlePanic(r)
+	}
+}
+
+func Catch(fn func() error) error {
+	return std().Catch(fn)
+}
+
+func SetRepanic(b bool) {
+	std().SetRepanic(b)
+}
+
+func Fatal(f interface{}, v ...interface{}) {
+	std().Fatal(logTemplate(f, v...), v...)
+}
+
+func Fatalf(format string, v ...interface{}) {
+	std().Fatalf(format, v...)
+}
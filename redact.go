@@ -0,0 +1,225 @@
+package loguru
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+type MaskStyle int
+
+const (
+	MaskFull MaskStyle = iota
+	MaskPartial
+	MaskHash
+)
+
+const redactedText = "[REDACTED]"
+
+type RedactConfig struct {
+	// Detectors names built-in detectors: "bearer", "email", "creditcard"
+	// and "ipv4".
+	Detectors []string
+	Patterns  []string
+	// DenyKeys masks the whole value of fields with these keys, ignoring case.
+	DenyKeys []string
+	Mask     MaskStyle
+}
+
+type detector struct {
+	re *regexp.Regexp
+	// group selects the submatch to mask; 0 masks the whole match.
+	group int
+	valid func(s string) bool
+}
+
+var builtinDetectors = map[string]detector{
+	"bearer":     {re: regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`), group: 1},
+	"email":      {re: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)},
+	"creditcard": {re: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`), valid: luhnValid},
+	"ipv4":       {re: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`)},
+}
+
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && n <= 19 && sum%10 == 0
+}
+
+// Redactor masks secrets and personal data in messages and field values.
+type Redactor struct {
+	count     uint64
+	detectors []detector
+	denyKeys  map[string]bool
+	mask      MaskStyle
+}
+
+func NewRedactor(cfg RedactConfig) (*Redactor, error) {
+	r := &Redactor{denyKeys: make(map[string]bool, len(cfg.DenyKeys)), mask: cfg.Mask}
+	for _, name := range cfg.Detectors {
+		d, ok := builtinDetectors[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("logs: unknown redaction detector %q", name)
+		}
+		r.detectors = append(r.detectors, d)
+	}
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("logs: invalid redaction pattern %q: %v", p, err)
+		}
+		r.detectors = append(r.detectors, detector{re: re})
+	}
+	for _, k := range cfg.DenyKeys {
+		r.denyKeys[strings.ToLower(k)] = true
+	}
+	return r, nil
+}
+
+// Count returns how many values r has masked so far.
+func (r *Redactor) Count() uint64 {
+	return atomic.LoadUint64(&r.count)
+}
+
+func (r *Redactor) maskValue(s string) string {
+	switch r.mask {
+	case MaskPartial:
+		if len(s) <= 4 {
+			return strings.Repeat("*", len(s))
+		}
+		return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+	case MaskHash:
+		sum := sha256.Sum256([]byte(s))
+		return "sha256:" + hex.EncodeToString(sum[:6])
+	default:
+		return redactedText
+	}
+}
+
+// RedactString masks every detector match in s and reports how many there
+// were.
+func (r *Redactor) RedactString(s string) (string, int) {
+	n := 0
+	for _, d := range r.detectors {
+		s = d.re.ReplaceAllStringFunc(s, func(match string) string {
+			if d.valid != nil && !d.valid(match) {
+				return match
+			}
+			n++
+			if d.group == 0 {
+				return r.maskValue(match)
+			}
+			sub := d.re.FindStringSubmatchIndex(match)
+			if len(sub) <= 2*d.group+1 || sub[2*d.group] < 0 {
+				return r.maskValue(match)
+			}
+			start, end := sub[2*d.group], sub[2*d.group+1]
+			return match[:start] + r.maskValue(match[start:end]) + match[end:]
+		})
+	}
+	return s, n
+}
+
+// Redact masks lm in place and returns the number of values masked. Field
+// values other than strings are scanned in their text form and replaced by
+// it when masked. Fields are copied before they are changed, so loggers
+// sharing them are untouched.
+func (r *Redactor) Redact(lm *LogMsg) int {
+	msg, total := r.RedactString(lm.Msg)
+	lm.Msg = msg
+
+	var fields []Field
+	for i, f := range lm.Fields {
+		value, n := f.Value, 0
+		if r.denyKeys[strings.ToLower(f.Key)] {
+			value, n = r.maskValue(fieldText(f.Value)), 1
+		} else if f.Value != nil {
+			value, n = r.RedactString(fieldText(f.Value))
+		}
+		if n == 0 {
+			continue
+		}
+		if fields == nil {
+			fields = append([]Field(nil), lm.Fields...)
+		}
+		fields[i].Value = value
+		total += n
+	}
+	if fields != nil {
+		lm.Fields = fields
+	}
+
+	if lm.Err != nil {
+		if text, n := r.RedactString(fmt.Sprint(lm.Err)); n > 0 {
+			lm.Err = errors.New(text)
+			total += n
+		}
+	}
+	if lm.Stack != "" {
+		stack, n := r.RedactString(lm.Stack)
+		lm.Stack = stack
+		total += n
+	}
+	if total > 0 {
+		atomic.AddUint64(&r.count, uint64(total))
+	}
+	return total
+}
+
+// SetRedactor masks every message logged through bl and its children with
+// r; nil turns redaction off.
+func (bl *Loguru) SetRedactor(r *Redactor) {
+	bl.lock.Lock()
+	bl.redactor = r
+	bl.lock.Unlock()
+}
+
+func (bl *Loguru) SetAdapterRedactor(adapterName string, r *Redactor) error {
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	found := false
+	for _, lg := range bl.outputs {
+		if lg.name == adapterName {
+			lg.redactor = r
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("logs: unknown adaptername %q (forgotten Register?)", adapterName)
+	}
+	return nil
+}
+
+func (bl *Loguru) redact(lm *LogMsg) {
+	for l := bl; l != nil; l = l.parent {
+		l.lock.Lock()
+		r := l.redactor
+		l.lock.Unlock()
+		if r != nil {
+			r.Redact(lm)
+		}
+	}
+}
+
+func SetRedactor(r *Redactor) {
+	std().SetRedactor(r)
+}
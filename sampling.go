@@ -0,0 +1,155 @@
+package loguru
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const maxSamplingKeys = 4096
+
+// Sampling lets through the First messages of each level and format string
+// within every Interval, then only every Thereafter-th one. Thereafter == 0
+// drops the rest of the interval.
+type Sampling struct {
+	First      int
+	Thereafter int
+	Interval   time.Duration
+}
+
+type samplingKey struct {
+	level  int
+	format string
+}
+
+type samplingCount struct {
+	start   time.Time
+	n       int
+	dropped int
+}
+
+type sampler struct {
+	dropped uint64
+	cfg     Sampling
+	mu      sync.Mutex
+	counts  map[samplingKey]*samplingCount
+}
+
+func newSampler(cfg *Sampling) *sampler {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	return &sampler{cfg: *cfg, counts: make(map[samplingKey]*samplingCount)}
+}
+
+// allow reports whether lm should be written. When earlier messages with the
+// same key were dropped, the count is appended to lm.Msg.
+func (s *sampler) allow(lm *LogMsg) bool {
+	key := samplingKey{level: lm.Level, format: lm.format}
+	if key.format == "" {
+		key.format = lm.Msg
+	}
+	s.mu.Lock()
+	c, ok := s.counts[key]
+	if !ok {
+		if len(s.counts) >= maxSamplingKeys {
+			s.prune(lm.When)
+		}
+		c = &samplingCount{start: lm.When}
+		s.counts[key] = c
+	}
+	if lm.When.Sub(c.start) >= s.cfg.Interval {
+		c.start = lm.When
+		c.n = 0
+	}
+	c.n++
+	pass := c.n <= s.cfg.First ||
+		(s.cfg.Thereafter > 0 && (c.n-s.cfg.First)%s.cfg.Thereafter == 0)
+	if !pass {
+		c.dropped++
+		s.mu.Unlock()
+		atomic.AddUint64(&s.dropped, 1)
+		return false
+	}
+	suppressed := c.dropped
+	c.dropped = 0
+	s.mu.Unlock()
+	if suppressed > 0 {
+		lm.Msg += fmt.Sprintf(" (%d similar messages suppressed)", suppressed)
+	}
+	return true
+}
+
+func (s *sampler) prune(now time.Time) {
+	for k, c := range s.counts {
+		if now.Sub(c.start) >= s.cfg.Interval && c.dropped == 0 {
+			delete(s.counts, k)
+		}
+	}
+}
+
+func (s *sampler) droppedCount() uint64 {
+	if s == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// SetSampling applies cfg to every message logged through bl and its
+// children; nil turns sampling off.
+func (bl *Loguru) SetSampling(cfg *Sampling) {
+	bl.lock.Lock()
+	bl.sampler = newSampler(cfg)
+	bl.lock.Unlock()
+}
+
+func (bl *Loguru) SetAdapterSampling(adapterName string, cfg *Sampling) error {
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	found := false
+	for _, lg := range bl.outputs {
+		if lg.name == adapterName {
+			lg.sampler = newSampler(cfg)
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("logs: unknown adaptername %q (forgotten Register?)", adapterName)
+	}
+	return nil
+}
+
+// SampledOut returns how many messages sampling has dropped on bl and, for
+// a root logger, on its outputs.
+func (bl *Loguru) SampledOut() uint64 {
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	n := bl.sampler.droppedCount()
+	if bl.parent == nil {
+		for _, lg := range bl.outputs {
+			n += lg.sampler.droppedCount()
+		}
+	}
+	return n
+}
+
+func (bl *Loguru) sampled(lm *LogMsg) bool {
+	for l := bl; l != nil; l = l.parent {
+		l.lock.Lock()
+		s := l.sampler
+		l.lock.Unlock()
+		if s != nil && !s.allow(lm) {
+			return false
+		}
+	}
+	return true
+}
+
+func SetSampling(cfg *Sampling) {
+	std().SetSampling(cfg)
+}
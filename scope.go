@@ -0,0 +1,67 @@
+package loguru
+
+import "time"
+
+type scope struct {
+	path    string
+	depth   int
+	started time.Time
+}
+
+// Timed returns a function that logs how long name took, at LevelInfo or,
+// when it took longer than the optional slow threshold, at LevelWarn:
+//
+//	defer logger.Timed("import users", time.Second)()
+func (bl *Loguru) Timed(name string, slow ...time.Duration) func() {
+	start := time.Now()
+	return func() {
+		d := time.Since(start)
+		level := LevelInfo
+		if len(slow) > 0 && d > slow[0] {
+			level = LevelWarn
+		}
+		if !bl.enabled(level) {
+			return
+		}
+		_ = bl.With("elapsed", d).writeMsg(level, "%s took %s", name, d)
+	}
+}
+
+// ScopeLogger is a logger for a named block of work. Its messages carry the scope
+// path, such as "import/users", and the time since the scope began, and the
+// console indents them by nesting depth.
+type ScopeLogger struct {
+	*Loguru
+	name string
+}
+
+// Scope logs entering name at LevelInfo and returns a logger for the block;
+// End logs leaving it with the elapsed time.
+func (bl *Loguru) Scope(name string) *ScopeLogger {
+	child := bl.withFields(nil)
+	child.scope = &scope{path: name, depth: 1, started: time.Now()}
+	if bl.scope != nil {
+		child.scope.path = bl.scope.path + "/" + name
+		child.scope.depth = bl.scope.depth + 1
+	}
+	if child.enabled(LevelInfo) {
+		_ = child.writeMsg(LevelInfo, "enter %s", name)
+	}
+	return &ScopeLogger{Loguru: child, name: name}
+}
+
+func (s *ScopeLogger) End() {
+	if !s.enabled(LevelInfo) {
+		return
+	}
+	d := time.Since(s.scope.started)
+	_ = s.With("elapsed", d).writeMsg(LevelInfo, "exit %s after %s", s.name, d)
+}
+
+func Timed(name string, slow ...time.Duration) func() {
+	return std().Timed(name, slow...)
+}
+
+func Scope(name string) *ScopeLogger {
+	return std().Scope(name)
+}
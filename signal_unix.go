@@ -0,0 +1,37 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package loguru
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// HandleLevelSignals makes SIGUSR1 raise the verbosity of bl by one level
+// and SIGUSR2 lower it. The returned func stops listening.
+func (bl *Loguru) HandleLevelSignals() (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		for {
+			select {
+			case sig := <-ch:
+				level := levelSeverity(bl.GetLevel())
+				if sig == syscall.SIGUSR1 && level < LevelDebug {
+					bl.changeLevel(level+1, "SIGUSR1")
+				} else if sig == syscall.SIGUSR2 && level > LevelEmergency {
+					bl.changeLevel(level-1, "SIGUSR2")
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
@@ -0,0 +1,47 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package loguru
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestHandleLevelSignals(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.SetLevel(LevelInfo)
+	stop := bl.HandleLevelSignals()
+	defer stop()
+
+	waitLevel := func(want int) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for bl.GetLevel() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("level is %d, want %d", bl.GetLevel(), want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	waitLevel(LevelInput)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatal(err)
+	}
+	waitLevel(LevelInfo)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatal(err)
+	}
+	waitLevel(LevelNotice)
+	time.Sleep(10 * time.Millisecond)
+	if lm := mw.last(); lm == nil || !strings.Contains(lm.Msg, "via SIGUSR2") {
+		t.Fatalf("level change was not logged: %v", lm)
+	}
+}
@@ -0,0 +1,225 @@
+package loguru
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+// SinkID identifies one output added with Add, so it can be removed without
+// touching other outputs of the same adapter.
+type SinkID int
+
+// addOutput appends lg to the outputs; bl.lock must be held.
+func (bl *Loguru) addOutput(name string, lg Logger) SinkID {
+	bl.lastSink++
+	bl.outputs = append(bl.outputs, &nameLogger{id: bl.lastSink, name: name, Logger: lg})
+	return bl.lastSink
+}
+
+// Add starts a new output of the named adapter. Unlike SetLogger, an adapter
+// can be added any number of times, for example to write to app.log and
+// errors.log at once.
+func (bl *Loguru) Add(adapterName string, config string) (SinkID, error) {
+	logAdapter, ok := adapters[adapterName]
+	if !ok {
+		return 0, fmt.Errorf("logs: unknown adaptername %q (forgotten Register?)", adapterName)
+	}
+	if config == "" {
+		config = "{}"
+	}
+	lg := logAdapter()
+	if err := lg.Init(config); err != nil {
+		return 0, err
+	}
+	return bl.addSink(adapterName, lg), nil
+}
+
+// Remove destroys the output id, added by Add or any other way.
+func (bl *Loguru) Remove(id SinkID) error {
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	for i, lg := range bl.outputs {
+		if lg.id == id {
+			lg.Destroy()
+			bl.outputs = append(bl.outputs[:i:i], bl.outputs[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("logs: unknown sink %d", id)
+}
+
+type sinkOptions struct {
+	level     int32
+	formatter LogFormatter
+	color     bool
+}
+
+type SinkOption func(o *sinkOptions)
+
+// SinkLevel sets the lowest level a sink writes; the default is LevelDebug.
+func SinkLevel(l int) SinkOption {
+	return func(o *sinkOptions) {
+		o.level = int32(l)
+	}
+}
+
+func SinkFormatter(f LogFormatter) SinkOption {
+	return func(o *sinkOptions) {
+		o.formatter = f
+	}
+}
+
+// SinkColor writes the console's colored format; the default is the plain
+// format of the file adapter.
+func SinkColor(b bool) SinkOption {
+	return func(o *sinkOptions) {
+		o.color = b
+	}
+}
+
+func newSinkOptions(opts []SinkOption) sinkOptions {
+	o := sinkOptions{level: LevelDebug}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+type writerSink struct {
+	sinkOptions
+	lg *logWriter
+	w  io.Writer
+}
+
+func (s *writerSink) Init(config string) error { return nil }
+
+func (s *writerSink) Format(lm *LogMsg) string {
+	return consoleFormat(lm, s.color)
+}
+
+func (s *writerSink) WriteMsg(lm *LogMsg) error {
+	if !levelEnabled(lm.Level, s.GetLevel()) {
+		return nil
+	}
+	f := s.formatter
+	if f == nil {
+		f = s
+	}
+	if lm.Raw {
+		_, err := s.lg.write(lm.Msg)
+		return err
+	}
+	_, err := s.lg.writeln(f.Format(lm))
+	return err
+}
+
+// Flush calls the Flush or Sync method of the writer, if it has one.
+func (s *writerSink) Flush() {
+	switch w := s.w.(type) {
+	case interface{ Flush() error }:
+		_ = w.Flush()
+	case interface{ Flush() }:
+		w.Flush()
+	case interface{ Sync() error }:
+		_ = w.Sync()
+	}
+}
+
+// Destroy flushes the writer and closes it if it is an io.Closer other than
+// os.Stdout or os.Stderr.
+func (s *writerSink) Destroy() {
+	s.Flush()
+	if s.w == os.Stdout || s.w == os.Stderr {
+		return
+	}
+	if c, ok := s.w.(io.Closer); ok {
+		_ = c.Close()
+	}
+}
+
+func (s *writerSink) SetFormatter(f LogFormatter) {
+	s.formatter = f
+}
+
+func (s *writerSink) SetLevel(l int) {
+	atomic.StoreInt32(&s.level, int32(l))
+}
+
+func (s *writerSink) GetLevel() int {
+	return int(atomic.LoadInt32(&s.level))
+}
+
+type funcSink struct {
+	sinkOptions
+	fn func(lm *LogMsg) error
+}
+
+func (s *funcSink) Init(config string) error { return nil }
+
+func (s *funcSink) WriteMsg(lm *LogMsg) error {
+	if !levelEnabled(lm.Level, s.GetLevel()) {
+		return nil
+	}
+	return s.fn(lm)
+}
+
+func (s *funcSink) Destroy() {}
+
+func (s *funcSink) Flush() {}
+
+func (s *funcSink) SetFormatter(f LogFormatter) {}
+
+func (s *funcSink) SetLevel(l int) {
+	atomic.StoreInt32(&s.level, int32(l))
+}
+
+func (s *funcSink) GetLevel() int {
+	return int(atomic.LoadInt32(&s.level))
+}
+
+// AddWriter adds an output that writes each message as a line to w.
+func (bl *Loguru) AddWriter(w io.Writer, opts ...SinkOption) (SinkID, error) {
+	if w == nil {
+		return 0, errors.New("logs: AddWriter provide is nil")
+	}
+	return bl.addSink("writer", &writerSink{sinkOptions: newSinkOptions(opts), lg: newLogWriter(w), w: w}), nil
+}
+
+// AddFunc adds an output that hands every message to fn.
+func (bl *Loguru) AddFunc(fn func(lm *LogMsg) error, opts ...SinkOption) (SinkID, error) {
+	if fn == nil {
+		return 0, errors.New("logs: AddFunc provide is nil")
+	}
+	return bl.addSink("func", &funcSink{sinkOptions: newSinkOptions(opts), fn: fn}), nil
+}
+
+func (bl *Loguru) addSink(name string, lg Logger) SinkID {
+	bl = bl.root()
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	if !bl.init {
+		bl.outputs = []*nameLogger{}
+		bl.init = true
+	}
+	return bl.addOutput(name, lg)
+}
+
+func Add(adapterName string, config string) (SinkID, error) {
+	return std().Add(adapterName, config)
+}
+
+func Remove(id SinkID) error {
+	return std().Remove(id)
+}
+
+func AddWriter(w io.Writer, opts ...SinkOption) (SinkID, error) {
+	return std().AddWriter(w, opts...)
+}
+
+func AddFunc(fn func(lm *LogMsg) error, opts ...SinkOption) (SinkID, error) {
+	return std().AddFunc(fn, opts...)
+}
@@ -0,0 +1,249 @@
+//go:build go1.21
+// +build go1.21
+
+package loguru
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const AdapterSlog = "slog"
+
+func fromSlogLevel(l slog.Level) int {
+	switch {
+	case l >= slog.LevelError+12:
+		return LevelEmergency
+	case l >= slog.LevelError+8:
+		return LevelAlert
+	case l >= slog.LevelError+4:
+		return LevelCritical
+	case l >= slog.LevelError:
+		return LevelError
+	case l >= slog.LevelWarn:
+		return LevelWarning
+	case l >= slog.LevelInfo+2:
+		return LevelNotice
+	case l >= slog.LevelInfo:
+		return LevelInfo
+	default:
+		return LevelDebug
+	}
+}
+
+func toSlogLevel(level int) slog.Level {
+	switch levelSeverity(level) {
+	case LevelEmergency:
+		return slog.LevelError + 12
+	case LevelAlert:
+		return slog.LevelError + 8
+	case LevelCritical:
+		return slog.LevelError + 4
+	case LevelError:
+		return slog.LevelError
+	case LevelWarning:
+		return slog.LevelWarn
+	case LevelNotice:
+		return slog.LevelInfo + 2
+	case LevelSuccess:
+		return slog.LevelInfo + 1
+	case LevelInformational:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}
+
+// SlogHandler is a slog.Handler that writes records through a Loguru.
+type SlogHandler struct {
+	l      *Loguru
+	fields []Field
+	group  string
+}
+
+func NewSlogHandler(l *Loguru) *SlogHandler {
+	return &SlogHandler{l: l}
+}
+
+func NewSlog(l *Loguru) *slog.Logger {
+	return slog.New(NewSlogHandler(l))
+}
+
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.l.enabled(fromSlogLevel(level))
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := make([]Field, 0, len(h.l.fields)+len(h.fields)+r.NumAttrs())
+	fields = append(fields, h.l.fields...)
+	fields = append(fields, h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.group, a)
+		return true
+	})
+	fields = append(fields, contextFields(ctx)...)
+
+	when := r.Time
+	if when.IsZero() {
+		when = time.Now()
+	}
+	lm := &LogMsg{Level: fromSlogLevel(r.Level), Msg: r.Message, When: when, Fields: fields, Name: h.l.name, format: r.Message}
+	if h.l.root().enableFuncCallDepth && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		lm.setCaller(frame)
+	}
+	h.l.emit(lm)
+	return nil
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	fields := make([]Field, 0, len(h.fields)+len(attrs))
+	fields = append(fields, h.fields...)
+	for _, a := range attrs {
+		fields = appendSlogAttr(fields, h.group, a)
+	}
+	return &SlogHandler{l: h.l, fields: fields, group: h.group}
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{l: h.l, fields: h.fields, group: h.group + name + "."}
+}
+
+func appendSlogAttr(fields []Field, group string, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		prefix := group
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendSlogAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+	return append(fields, Field{Key: group + a.Key, Value: a.Value.Any()})
+}
+
+var slogHandlers = struct {
+	sync.RWMutex
+	handlers map[string]slog.Handler
+}{
+	handlers: map[string]slog.Handler{},
+}
+
+// RegisterSlogHandler makes h available to the slog adapter under name.
+func RegisterSlogHandler(name string, h slog.Handler) {
+	if h == nil {
+		panic("logs: RegisterSlogHandler provide is nil")
+	}
+	if _, ok := h.(*SlogHandler); ok {
+		panic("logs: RegisterSlogHandler would loop back into loguru")
+	}
+	slogHandlers.Lock()
+	slogHandlers.handlers[name] = h
+	slogHandlers.Unlock()
+}
+
+type slogWriter struct {
+	handler slog.Handler
+	Handler string `json:"handler"`
+	Level   int32  `json:"level"`
+}
+
+func newSlogWriter() Logger {
+	return &slogWriter{Level: LevelDebug}
+}
+
+func (s *slogWriter) Init(config string) error {
+	if len(config) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(config), s); err != nil {
+		return err
+	}
+	if s.Handler == "" {
+		return nil
+	}
+	slogHandlers.RLock()
+	h, ok := slogHandlers.handlers[s.Handler]
+	slogHandlers.RUnlock()
+	if !ok {
+		return fmt.Errorf("the slog handler with name: %s not found", s.Handler)
+	}
+	s.handler = h
+	return nil
+}
+
+func (s *slogWriter) WriteMsg(lm *LogMsg) error {
+	if !levelEnabled(lm.Level, s.GetLevel()) {
+		return nil
+	}
+	h := s.handler
+	if h == nil {
+		h = slog.Default().Handler()
+		if _, ok := h.(*SlogHandler); ok {
+			return nil
+		}
+		// The default handler writes through the log package, which
+		// RedirectStdLog may have pointed back at loguru.
+		if _, ok := log.Writer().(*stdLogWriter); ok {
+			return nil
+		}
+	}
+	ctx := context.Background()
+	level := toSlogLevel(lm.Level)
+	if !h.Enabled(ctx, level) {
+		return nil
+	}
+	r := slog.NewRecord(lm.When, level, lm.Msg, 0)
+	if lm.Name != "" {
+		r.AddAttrs(slog.String("logger", lm.Name))
+	}
+	for _, f := range lm.Fields {
+		r.AddAttrs(slog.Any(f.Key, f.Value))
+	}
+	if lm.Err != nil {
+		r.AddAttrs(slog.Any("error", lm.Err))
+	}
+	if lm.Scope != "" {
+		r.AddAttrs(slog.String("scope", lm.Scope), slog.Duration("scope_elapsed", lm.ScopeElapsed))
+	}
+	if lm.FilePath != "" {
+		r.AddAttrs(slog.Any(slog.SourceKey, &slog.Source{Function: lm.FuncName, File: lm.FilePath, Line: lm.LineNumber}))
+	}
+	return h.Handle(ctx, r)
+}
+
+func (s *slogWriter) SetLevel(l int) {
+	atomic.StoreInt32(&s.Level, int32(l))
+}
+
+func (s *slogWriter) GetLevel() int {
+	return int(atomic.LoadInt32(&s.Level))
+}
+
+func (s *slogWriter) SetFormatter(f LogFormatter) {}
+
+func (s *slogWriter) Destroy() {}
+
+func (s *slogWriter) Flush() {}
+
+func init() {
+	Register(AdapterSlog, newSlogWriter)
+}
@@ -0,0 +1,69 @@
+//go:build go1.21
+// +build go1.21
+
+package loguru
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlogHandler(t *testing.T) {
+	bl, mw := newMemoryLogger()
+	bl.SetLevel(LevelInfo)
+	sl := NewSlog(bl).With("service", "api").WithGroup("req")
+
+	sl.Debug("hidden")
+	sl.Warn("slow request", "path", "/users", slog.Group("user", "id", 7))
+	if len(mw.msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(mw.msgs))
+	}
+	lm := mw.last()
+	if lm.Level != LevelWarning || !strings.HasSuffix(lm.Msg, "slow request") {
+		t.Fatalf("unexpected message %d %q", lm.Level, lm.Msg)
+	}
+	keys := []string{}
+	for _, f := range lm.Fields {
+		keys = append(keys, f.Key)
+	}
+	if got := strings.Join(keys, ","); got != "service,req.path,req.user.id" {
+		t.Fatalf("unexpected keys %s", got)
+	}
+}
+
+func TestSlogAdapter(t *testing.T) {
+	var buf bytes.Buffer
+	RegisterSlogHandler("test", slog.NewTextHandler(&buf, nil))
+	bl := NewLogger(0)
+	bl.EnableFuncCallDepth(false)
+	if err := bl.SetLogger(AdapterSlog, `{"handler": "test"}`); err != nil {
+		t.Fatal(err)
+	}
+	bl.With("user", 42).Error("failed")
+	out := buf.String()
+	if !strings.Contains(out, "level=ERROR") || !strings.Contains(out, "msg=failed") || !strings.Contains(out, "user=42") {
+		t.Fatalf("unexpected slog output %q", out)
+	}
+}
+
+func TestSlogAdapterRedirectedStdLog(t *testing.T) {
+	bl := NewLogger(0)
+	if err := bl.SetLogger(AdapterSlog, ""); err != nil {
+		t.Fatal(err)
+	}
+	restore := RedirectStdLog(bl, LevelInfo)
+	done := make(chan struct{})
+	go func() {
+		bl.Info("x")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("logging through the default slog handler deadlocked")
+	}
+	restore()
+}
@@ -0,0 +1,204 @@
+package loguru
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const stackIndent = "    "
+
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+// ErrorChain returns err followed by every error it wraps. A typed nil
+// pointer ends the chain, since unwrapping it would panic.
+func ErrorChain(err error) []error {
+	var chain []error
+	for ; err != nil; err = errors.Unwrap(err) {
+		chain = append(chain, err)
+		if v := reflect.ValueOf(err); v.Kind() == reflect.Ptr && v.IsNil() {
+			break
+		}
+	}
+	return chain
+}
+
+// errorStack formats the innermost github.com/pkg/errors stack trace in the
+// chain of err, which is the one closest to where the error was created.
+func errorStack(err error) string {
+	var st errors.StackTrace
+	for _, e := range ErrorChain(err) {
+		if s, ok := e.(stackTracer); ok {
+			st = s.StackTrace()
+		}
+	}
+	if st == nil {
+		return ""
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%+v", st), "\n")
+}
+
+var selfPackage = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	return funcPackage(runtime.FuncForPC(pc).Name())
+}()
+
+// funcPackage returns the import path part of a qualified function name
+// such as "github.com/pkg/errors.(*withStack).Format".
+func funcPackage(name string) string {
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+	return name
+}
+
+func isSelfFrame(f runtime.Frame) bool {
+	return funcPackage(f.Function) == selfPackage && !strings.HasSuffix(f.File, "_test.go")
+}
+
+// callerFrames returns the stack of the calling goroutine without the frames
+// of this package above the code that logged. With afterPanic, everything
+// up to the runtime's panic machinery is dropped as well so the stack starts
+// where the panic was raised.
+func callerFrames(afterPanic bool) []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	var frames []runtime.Frame
+	it := runtime.CallersFrames(pcs[:n])
+	for more := n > 0; more; {
+		var f runtime.Frame
+		f, more = it.Next()
+		if afterPanic && f.Function == "runtime.gopanic" {
+			frames = frames[:0]
+			continue
+		}
+		if len(frames) == 0 && (isSelfFrame(f) || isHelper(f.Function)) {
+			continue
+		}
+		frames = append(frames, f)
+	}
+	return frames
+}
+
+func formatFrames(frames []runtime.Frame) string {
+	var b strings.Builder
+	for i, f := range frames {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(f.Function + "\n\t" + f.File + ":" + strconv.Itoa(f.Line))
+	}
+	return b.String()
+}
+
+func callerStack() string {
+	return formatFrames(callerFrames(false))
+}
+
+func (lm *LogMsg) errorField(key brush) string {
+	if lm.Err == nil {
+		return ""
+	}
+	return formatFields([]Field{{Key: "error", Value: fmt.Sprint(lm.Err)}}, key)
+}
+
+// errorDetails renders the wrapped causes of lm.Err and lm.Stack as
+// indented lines that belong below the message.
+func (lm *LogMsg) errorDetails() string {
+	var b strings.Builder
+	prev := ""
+	for i, e := range ErrorChain(lm.Err) {
+		text := fmt.Sprint(e)
+		if i > 0 && text != prev {
+			b.WriteString("\n" + stackIndent + "caused by: " + text)
+		}
+		prev = text
+	}
+	if lm.Stack != "" {
+		for _, line := range strings.Split(lm.Stack, "\n") {
+			b.WriteString("\n" + stackIndent + line)
+		}
+	}
+	return b.String()
+}
+
+// WithError returns a child logger whose messages carry err; its wrapped
+// causes and github.com/pkg/errors stack trace are rendered with them.
+func (bl *Loguru) WithError(err error) *Loguru {
+	child := bl.withFields(nil)
+	child.err = err
+	return child
+}
+
+// SetStackTraceLevel captures the goroutine stack for messages at level or
+// more severe that do not already carry one from their error. Use -1 to
+// turn it off.
+func (bl *Loguru) SetStackTraceLevel(level int) {
+	bl.root().stackLevel = level
+}
+
+func (bl *Loguru) EmergencyErr(err error, format string, v ...interface{}) {
+	if !bl.enabled(LevelEmergency) {
+		return
+	}
+	_ = bl.WithError(err).writeMsg(LevelEmergency, format, v...)
+}
+
+func (bl *Loguru) AlertErr(err error, format string, v ...interface{}) {
+	if !bl.enabled(LevelAlert) {
+		return
+	}
+	_ = bl.WithError(err).writeMsg(LevelAlert, format, v...)
+}
+
+func (bl *Loguru) CriticalErr(err error, format string, v ...interface{}) {
+	if !bl.enabled(LevelCritical) {
+		return
+	}
+	_ = bl.WithError(err).writeMsg(LevelCritical, format, v...)
+}
+
+func (bl *Loguru) ErrorErr(err error, format string, v ...interface{}) {
+	if !bl.enabled(LevelError) {
+		return
+	}
+	_ = bl.WithError(err).writeMsg(LevelError, format, v...)
+}
+
+func (bl *Loguru) WarnErr(err error, format string, v ...interface{}) {
+	if !bl.enabled(LevelWarn) {
+		return
+	}
+	_ = bl.WithError(err).writeMsg(LevelWarn, format, v...)
+}
+
+func EmergencyErr(err error, f interface{}, v ...interface{}) {
+	std().EmergencyErr(err, logTemplate(f, v...), v...)
+}
+
+func AlertErr(err error, f interface{}, v ...interface{}) {
+	std().AlertErr(err, logTemplate(f, v...), v...)
+}
+
+func CriticalErr(err error, f interface{}, v ...interface{}) {
+	std().CriticalErr(err, logTemplate(f, v...), v...)
+}
+
+func ErrorErr(err error, f interface{}, v ...interface{}) {
+	std().ErrorErr(err, logTemplate(f, v...), v...)
+}
+
+func WarnErr(err error, f interface{}, v ...interface{}) {
+	std().WarnErr(err, logTemplate(f, v...), v...)
+}
+
+func SetStackTraceLevel(level int) {
+	std().SetStackTraceLevel(level)
+}
@@ -0,0 +1,138 @@
+package loguru
+
+import (
+	"bytes"
+	"log"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var stdLogPrefixes = struct {
+	sync.RWMutex
+	levels map[string]int
+}{
+	levels: map[string]int{},
+}
+
+// levelMarker matches "[ERROR] " or "warn: " at the start of a line.
+var levelMarker = regexp.MustCompile(`^(?:\[([A-Za-z]+)\]|([A-Za-z]+):)\s*`)
+
+// SetStdLogPrefixLevel logs standard library log lines starting with prefix,
+// such as the "[DB] " of GetLogger("db"), at level unless the line carries a
+// level marker of its own.
+func SetStdLogPrefixLevel(prefix string, level int) {
+	stdLogPrefixes.Lock()
+	stdLogPrefixes.levels[prefix] = level
+	stdLogPrefixes.Unlock()
+}
+
+// stdLogLevel picks the level of a standard library log line and returns the
+// line without its level marker.
+func stdLogLevel(line string, defaultLevel int) (int, string) {
+	level, head := defaultLevel, ""
+	stdLogPrefixes.RLock()
+	for prefix, l := range stdLogPrefixes.levels {
+		if strings.HasPrefix(line, prefix) && len(prefix) > len(head) {
+			level, head = l, prefix
+		}
+	}
+	stdLogPrefixes.RUnlock()
+
+	// The marker may follow a tag such as the "[APP] " of GetLogger("app").
+	for i := 0; i < 2; i++ {
+		rest := line[len(head):]
+		m := levelMarker.FindStringSubmatchIndex(rest)
+		if m == nil {
+			break
+		}
+		start, end := m[2], m[3]
+		if start < 0 {
+			start, end = m[4], m[5]
+		}
+		if l, err := ParseLevel(rest[start:end]); err == nil {
+			return l, head + rest[m[1]:]
+		}
+		if m[2] < 0 {
+			break
+		}
+		head += rest[:m[1]]
+	}
+	return level, line
+}
+
+type stdLogWriter struct {
+	mu sync.Mutex
+	// writing holds the goroutines that are logging a line right now.
+	writing      map[uint64]bool
+	l            *Loguru
+	defaultLevel int
+}
+
+// Write drops lines that a goroutine writes while it is still logging a
+// previous one, which happens when an adapter writes back through the log
+// package. Lines from other goroutines are logged as usual.
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	id := goroutineID()
+	w.mu.Lock()
+	if w.writing[id] {
+		w.mu.Unlock()
+		return len(p), nil
+	}
+	if w.writing == nil {
+		w.writing = make(map[uint64]bool)
+	}
+	w.writing[id] = true
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		delete(w.writing, id)
+		w.mu.Unlock()
+	}()
+	return w.l.writeStd(p, w.defaultLevel)
+}
+
+// goroutineID parses the id of the current goroutine from the header of its
+// stack trace, "goroutine 42 [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+func (bl *Loguru) writeStd(p []byte, defaultLevel int) (int, error) {
+	line := strings.TrimSuffix(string(p), "\n")
+	if line == "" {
+		return len(p), nil
+	}
+	level, msg := stdLogLevel(line, defaultLevel)
+	if !bl.enabled(level) {
+		return len(p), nil
+	}
+	if err := bl.writeMsg(level, msg); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// RedirectStdLog sends the output of the standard library's log package to
+// l. Lines are logged at defaultLevel unless a level marker or a prefix set
+// with SetStdLogPrefixLevel says otherwise. restore puts back the previous
+// output, flags and prefix.
+func RedirectStdLog(l *Loguru, defaultLevel int) (restore func()) {
+	out, flags, prefix := log.Writer(), log.Flags(), log.Prefix()
+	log.SetOutput(&stdLogWriter{l: l, defaultLevel: defaultLevel})
+	log.SetFlags(0)
+	return func() {
+		log.SetOutput(out)
+		log.SetFlags(flags)
+		log.SetPrefix(prefix)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package loguru
+
+import "errors"
+
+func isTerminal(fd int) bool {
+	return false
+}
+
+func withEchoOff(fd int, fn func()) error {
+	return errors.New("logs: cannot turn off terminal echo on this platform")
+}
@@ -0,0 +1,42 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package loguru
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+func ioctlTermios(fd int, req uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), req, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func isTerminal(fd int) bool {
+	var t syscall.Termios
+	return ioctlTermios(fd, ioctlReadTermios, &t) == nil
+}
+
+// withEchoOff runs fn with the terminal on fd not echoing what is typed.
+func withEchoOff(fd int, fn func()) error {
+	var old syscall.Termios
+	if err := ioctlTermios(fd, ioctlReadTermios, &old); err != nil {
+		return err
+	}
+	t := old
+	t.Lflag &^= syscall.ECHO
+	t.Lflag |= syscall.ICANON | syscall.ISIG
+	t.Iflag |= syscall.ICRNL
+	if err := ioctlTermios(fd, ioctlWriteTermios, &t); err != nil {
+		return err
+	}
+	defer func() {
+		_ = ioctlTermios(fd, ioctlWriteTermios, &old)
+	}()
+	fn()
+	return nil
+}